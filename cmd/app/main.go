@@ -28,13 +28,29 @@ func main() {
 	userRepo := postgres.NewUserRepository(database)
 	pullRequestRepo := postgres.NewPullRequestRepository(database)
 	statsRepo := postgres.NewStatsRepository(database)
+	rotationRepo := postgres.NewRotationRepository(database)
+	unavailabilityRepo := postgres.NewUnavailabilityRepository(database)
+	codeOwnersRepo := postgres.NewCodeOwnersRepository(database)
+
+	reviewerSelector, err := service.NewTeamStrategySelector(cfg.Reviewer.DefaultStrategy, cfg.Reviewer.TeamStrategies, pullRequestRepo, rotationRepo)
+	if err != nil {
+		log.Fatalf("Invalid reviewer selection config: %v", err)
+	}
+	reviewerSelector = service.NewAntiRepetitionSelector(reviewerSelector, pullRequestRepo, cfg.Reviewer.RepeatLookback)
+
+	if cfg.Reviewer.SeedSecret == "" {
+		log.Println("Warning: REVIEWER_SEED_SECRET is not set, reviewer selection seeds can be derived from PR IDs alone")
+	}
+	assignmentSeeder := service.NewAssignmentSeeder(cfg.Reviewer.SeedSecret)
 
 	teamService := service.NewTeamService(database, teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, pullRequestRepo)
-	pullRequestService := service.NewPullRequestService(pullRequestRepo, userRepo, teamRepo)
+	userService := service.NewUserService(database, userRepo, pullRequestRepo, reviewerSelector, assignmentSeeder)
+	pullRequestService := service.NewPullRequestService(database, pullRequestRepo, userRepo, teamRepo, codeOwnersRepo, reviewerSelector, assignmentSeeder)
 	statsService := service.NewStatsService(statsRepo)
+	unavailabilityService := service.NewUnavailabilityService(unavailabilityRepo, userRepo, pullRequestRepo, pullRequestService)
+	codeOwnersService := service.NewCodeOwnersService(codeOwnersRepo)
 
-	h := handler.NewHandler(teamService, userService, pullRequestService, statsService)
+	h := handler.NewHandler(teamService, userService, pullRequestService, statsService, unavailabilityService, codeOwnersService)
 	srv := server.NewServer(h, ":8080")
 
 	go func() {
@@ -43,9 +59,14 @@ func main() {
 		}
 	}()
 
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go runUnavailabilityJob(jobCtx, unavailabilityService, cfg.Jobs.UnavailabilityInterval)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -54,3 +75,24 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 }
+
+// runUnavailabilityJob периодически переназначает OPEN PR пользователей, чье отсутствие началось
+func runUnavailabilityJob(ctx context.Context, unavailabilityService service.UnavailabilityService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := unavailabilityService.ReassignStartedUnavailability(ctx)
+		if err != nil {
+			log.Printf("Unavailability job failed: %v", err)
+		} else if result.Reassigned > 0 || len(result.Skipped) > 0 {
+			log.Printf("Unavailability job: reassigned %d reviews, no candidate for PRs %v", result.Reassigned, result.Skipped)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
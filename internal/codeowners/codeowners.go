@@ -0,0 +1,202 @@
+// Package codeowners разбирает файлы в формате CODEOWNERS и определяет владельцев путей.
+//
+// Каждая непустая строка файла (кроме комментариев, начинающихся с #) содержит glob-шаблон
+// и список владельцев через пробел. Владелец - пользователь (@u1) или команда (@org/backend,
+// используется имя команды после косой черты). Для пути применяется последнее подходящее правило,
+// как в GitHub.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Owner - владелец из CODEOWNERS: пользователь или команда
+type Owner struct {
+	UserID   string
+	TeamName string
+}
+
+// IsTeam сообщает, является ли владелец командой
+func (o Owner) IsTeam() bool {
+	return o.TeamName != ""
+}
+
+// String возвращает владельца в записи CODEOWNERS
+func (o Owner) String() string {
+	if o.IsTeam() {
+		return "@team/" + o.TeamName
+	}
+	return "@" + o.UserID
+}
+
+// Rule - одно правило CODEOWNERS. Правило без владельцев снимает владение с подходящих путей.
+type Rule struct {
+	Pattern string
+	Owners  []Owner
+	Line    int
+}
+
+// Ruleset - разобранный файл CODEOWNERS
+type Ruleset struct {
+	rules    []Rule
+	matchers []*regexp.Regexp
+}
+
+// Parse разбирает содержимое файла CODEOWNERS.
+// Возвращает ошибку с номером строки при некорректном шаблоне или владельце.
+func Parse(content string) (*Ruleset, error) {
+	ruleset := &Ruleset{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		fields := strings.Fields(line)
+		pattern := fields[0]
+
+		matcher, err := compilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		owners := make([]Owner, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			owner, err := parseOwner(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			owners = append(owners, owner)
+		}
+
+		ruleset.rules = append(ruleset.rules, Rule{Pattern: pattern, Owners: owners, Line: lineNumber})
+		ruleset.matchers = append(ruleset.matchers, matcher)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ruleset, nil
+}
+
+// Rules возвращает правила в порядке следования в файле
+func (rs *Ruleset) Rules() []Rule {
+	return rs.rules
+}
+
+// Match возвращает последнее правило, подходящее для path, или nil
+func (rs *Ruleset) Match(path string) *Rule {
+	path = normalizePath(path)
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		if rs.matchers[i].MatchString(path) {
+			return &rs.rules[i]
+		}
+	}
+	return nil
+}
+
+// OwnersOf возвращает владельцев путей paths без повторов, в порядке первого появления
+func (rs *Ruleset) OwnersOf(paths []string) []Owner {
+	seen := make(map[Owner]bool)
+	owners := make([]Owner, 0)
+	for _, path := range paths {
+		rule := rs.Match(path)
+		if rule == nil {
+			continue
+		}
+		for _, owner := range rule.Owners {
+			if seen[owner] {
+				continue
+			}
+			seen[owner] = true
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// parseOwner разбирает владельца вида @u1 или @org/team
+func parseOwner(field string) (Owner, error) {
+	name, ok := strings.CutPrefix(field, "@")
+	if !ok || name == "" {
+		return Owner{}, fmt.Errorf("invalid owner %q: must start with @", field)
+	}
+
+	if _, teamName, isTeam := strings.Cut(name, "/"); isTeam {
+		if teamName == "" {
+			return Owner{}, fmt.Errorf("invalid owner %q: empty team name", field)
+		}
+		return Owner{TeamName: teamName}, nil
+	}
+
+	return Owner{UserID: name}, nil
+}
+
+// compilePattern переводит glob-шаблон CODEOWNERS в регулярное выражение.
+// Шаблон с косой чертой в начале или середине привязан к корню репозитория, без нее - совпадает на любой глубине.
+// Шаблон, совпавший с каталогом, распространяется на все его содержимое, если последний сегмент шаблона
+// не содержит подстановочных символов: docs/* совпадает только с непосредственным содержимым docs.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	lastSegment := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	switch {
+	case strings.HasSuffix(pattern, "/"):
+		expr.WriteString("/.*$")
+	case strings.ContainsAny(lastSegment, "*?"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(expr.String())
+}
+
+// normalizePath приводит путь к виду относительно корня репозитория
+func normalizePath(path string) string {
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,132 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("разбор правил с комментариями и пустыми строками", func(t *testing.T) {
+		content := `
+# Владельцы по умолчанию
+*                 @u1
+
+/internal/        @org/backend @u2   # сервисный слой
+docs/**           @u3
+`
+		ruleset, err := Parse(content)
+
+		require.NoError(t, err)
+		rules := ruleset.Rules()
+		require.Len(t, rules, 3)
+		assert.Equal(t, "*", rules[0].Pattern)
+		assert.Equal(t, []Owner{{UserID: "u1"}}, rules[0].Owners)
+		assert.Equal(t, []Owner{{TeamName: "backend"}, {UserID: "u2"}}, rules[1].Owners)
+		assert.Equal(t, 5, rules[1].Line)
+	})
+
+	t.Run("правило без владельцев допустимо", func(t *testing.T) {
+		ruleset, err := Parse("/vendor/")
+
+		require.NoError(t, err)
+		require.Len(t, ruleset.Rules(), 1)
+		assert.Empty(t, ruleset.Rules()[0].Owners)
+	})
+
+	t.Run("ошибка: владелец без @", func(t *testing.T) {
+		ruleset, err := Parse("*.go @u1\n*.sql u2")
+
+		require.Error(t, err)
+		assert.Nil(t, ruleset)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+
+	t.Run("ошибка: пустое имя команды", func(t *testing.T) {
+		_, err := Parse("*.go @org/")
+
+		require.Error(t, err)
+	})
+
+	t.Run("ошибка: отрицающий шаблон не поддерживается", func(t *testing.T) {
+		_, err := Parse("!*.go @u1")
+
+		require.Error(t, err)
+	})
+}
+
+func TestRuleset_Match(t *testing.T) {
+	ruleset, err := Parse(`
+*.md              @u1
+/cmd/             @u2
+internal/service  @u3
+**/migrations     @u4
+/api/*.yaml       @u5
+testdata/         @u6
+/Makefile
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		pattern string
+	}{
+		{"шаблон без косой черты совпадает на любой глубине", "docs/guide/README.md", "*.md"},
+		{"каталог от корня распространяется на вложенные файлы", "cmd/server/main.go", "/cmd/"},
+		{"шаблон с косой чертой в середине привязан к корню", "internal/service/team_service.go", "internal/service"},
+		{"** совпадает с любым количеством каталогов", "db/postgres/migrations/000001_init.up.sql", "**/migrations"},
+		{"* не переходит через косую черту", "api/openapi.yaml", "/api/*.yaml"},
+		{"каталог без привязки совпадает на любой глубине", "internal/codeowners/testdata/CODEOWNERS", "testdata/"},
+		{"ведущая косая черта в пути игнорируется", "/Makefile", "/Makefile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := ruleset.Match(tt.path)
+
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.pattern, rule.Pattern)
+		})
+	}
+
+	t.Run("путь без подходящего правила", func(t *testing.T) {
+		assert.Nil(t, ruleset.Match("go.mod"))
+		assert.Nil(t, ruleset.Match("pkg/internal/service/x.go"), "привязанный к корню шаблон не совпадает глубже")
+		assert.Nil(t, ruleset.Match("api/v1/openapi.yaml"), "* не совпадает с вложенными каталогами")
+	})
+
+	t.Run("шаблон с * в последнем сегменте не распространяется на подкаталоги", func(t *testing.T) {
+		ruleset, err := Parse("docs/*  @u1")
+		require.NoError(t, err)
+
+		assert.NotNil(t, ruleset.Match("docs/getting-started.md"))
+		assert.Nil(t, ruleset.Match("docs/sub/file.md"))
+	})
+}
+
+func TestRuleset_OwnersOf(t *testing.T) {
+	ruleset, err := Parse(`
+*                 @u1
+/internal/        @org/backend @u2
+/internal/vendor/
+`)
+	require.NoError(t, err)
+
+	t.Run("применяется последнее подходящее правило, владельцы без повторов", func(t *testing.T) {
+		owners := ruleset.OwnersOf([]string{
+			"internal/service/a.go",
+			"internal/handler/b.go",
+			"README.md",
+		})
+
+		assert.Equal(t, []Owner{{TeamName: "backend"}, {UserID: "u2"}, {UserID: "u1"}}, owners)
+	})
+
+	t.Run("правило без владельцев снимает владение", func(t *testing.T) {
+		owners := ruleset.OwnersOf([]string{"internal/vendor/lib.go"})
+
+		assert.Empty(t, owners)
+	})
+}
@@ -2,12 +2,17 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	Database DatabaseConfig
+	Reviewer ReviewerConfig
+	Jobs     JobsConfig
 }
 
 type DatabaseConfig struct {
@@ -19,6 +24,23 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// ReviewerConfig задает стратегии выбора ревьюверов.
+// TeamStrategies переопределяет DefaultStrategy для отдельных команд (имя команды -> стратегия).
+// SeedSecret - секрет, из которого вместе с ID PR выводится seed выбора ревьюверов.
+// RepeatLookback - сколько последних PR автора учитывается при понижении веса его частых ревьюверов (0 - не учитывать).
+type ReviewerConfig struct {
+	DefaultStrategy string
+	TeamStrategies  map[string]string
+	SeedSecret      string
+	RepeatLookback  int
+}
+
+// JobsConfig задает периодичность фоновых задач
+type JobsConfig struct {
+	// UnavailabilityInterval - как часто переназначаются ревью пользователей, чье отсутствие началось
+	UnavailabilityInterval time.Duration
+}
+
 func Load() *Config {
 	_ = godotenv.Load()
 
@@ -31,6 +53,15 @@ func Load() *Config {
 			DBName:   getEnv("DB_NAME", "pr_reviewer"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Reviewer: ReviewerConfig{
+			DefaultStrategy: getEnv("REVIEWER_STRATEGY", "least_loaded"),
+			TeamStrategies:  getEnvMap("REVIEWER_TEAM_STRATEGIES"),
+			SeedSecret:      getEnv("REVIEWER_SEED_SECRET", ""),
+			RepeatLookback:  getEnvInt("REVIEWER_REPEAT_LOOKBACK", 5),
+		},
+		Jobs: JobsConfig{
+			UnavailabilityInterval: getEnvDuration("UNAVAILABILITY_JOB_INTERVAL", time.Minute),
+		},
 	}
 }
 
@@ -40,3 +71,37 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt разбирает неотрицательное целое; при отсутствии или ошибке разбора возвращает defaultValue
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration разбирает переменную окружения в формате time.ParseDuration (например, "30s", "5m")
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvMap разбирает переменную окружения вида "key1=value1,key2=value2"
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
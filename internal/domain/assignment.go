@@ -0,0 +1,130 @@
+package domain
+
+import "time"
+
+// AssignmentEvent - событие, при котором на PR были выбраны или изменены ревьюверы
+type AssignmentEvent string
+
+const (
+	AssignmentCreate AssignmentEvent = "CREATE"
+	// AssignmentMarkReady - выбор ревьюверов при переводе черновика в OPEN, как при создании PR
+	AssignmentMarkReady AssignmentEvent = "MARK_READY"
+	AssignmentReassign  AssignmentEvent = "REASSIGN"
+	// AssignmentManualReassign - замена ревьювера на пользователя, указанного явно;
+	// случайно выбираются только ревьюверы, добранные до min_reviewers
+	AssignmentManualReassign AssignmentEvent = "MANUAL_REASSIGN"
+	// AssignmentAddReviewer и AssignmentRemoveReviewer - ручное добавление и снятие ревьювера, без случайного выбора
+	AssignmentAddReviewer    AssignmentEvent = "ADD_REVIEWER"
+	AssignmentRemoveReviewer AssignmentEvent = "REMOVE_REVIEWER"
+)
+
+// Assignment - запись об одном выборе ревьюверов на PR.
+// Вместе с seed хранятся входные данные, которых нет в PR, чтобы выбор можно было воспроизвести.
+type Assignment struct {
+	ID            int
+	PullRequestID string
+	Event         AssignmentEvent
+	// Seed - начальное значение источника случайности, выведенное из ID PR, события и секрета сервера
+	Seed int64
+	// ReplacedReviewerID - заменяемый ревьювер (для REASSIGN и MANUAL_REASSIGN) или снятый ревьювер (для REMOVE_REVIEWER)
+	ReplacedReviewerID string
+	// PreviousReviewers - ревьюверы PR до выбора (для REASSIGN и MANUAL_REASSIGN)
+	PreviousReviewers []string
+	// Repository и ChangedFiles - входные данные выбора по CODEOWNERS (для CREATE и MARK_READY)
+	Repository   string
+	ChangedFiles []string
+	// SelectedReviewers - выбранные ревьюверы в порядке выбора
+	SelectedReviewers []string
+	// Steps - трассировка выбора: кандидаты, исключенные и выбранные на каждом шаге
+	Steps     []*SelectionStep
+	CreatedAt time.Time
+}
+
+// SelectionSource - из кого выбирались ревьюверы на шаге выбора
+type SelectionSource string
+
+const (
+	SourceMandatory    SelectionSource = "MANDATORY"
+	SourceCodeOwners   SelectionSource = "CODEOWNERS"
+	SourceTeam         SelectionSource = "TEAM"
+	SourceFallbackTeam SelectionSource = "FALLBACK_TEAM"
+)
+
+// ExclusionReason - причина, по которой пользователь не был кандидатом
+type ExclusionReason string
+
+const (
+	ExclusionAuthor          ExclusionReason = "AUTHOR"
+	ExclusionCoAuthor        ExclusionReason = "CO_AUTHOR"
+	ExclusionAlreadyAssigned ExclusionReason = "ALREADY_ASSIGNED"
+	ExclusionInactive        ExclusionReason = "INACTIVE"
+	ExclusionUnavailable     ExclusionReason = "UNAVAILABLE"
+	ExclusionAtCapacity      ExclusionReason = "AT_CAPACITY"
+)
+
+// SelectionStep - один шаг выбора ревьюверов (обязательные ревьюверы, владельцы по CODEOWNERS, команда, резервная команда)
+type SelectionStep struct {
+	Source   SelectionSource
+	TeamName string
+	// Strategy - стратегия выбора; для обязательных ревьюверов пустая
+	Strategy string
+	// Candidates - пул кандидатов, из которого выбирала стратегия
+	Candidates []string
+	Excluded   []*ExcludedCandidate
+	Selected   []string
+	// Inputs - данные, на которых стратегия выбирала на шаге; nil для обязательных ревьюверов
+	// и для записей, сохраненных до того, как входные данные стали записываться
+	Inputs *SelectionInputs
+}
+
+// SelectionInputs - входные данные стратегии на шаге выбора. Вместе с seed назначения они позволяют
+// повторить выбор, не читая текущие данные БД, которые само назначение уже изменило.
+type SelectionInputs struct {
+	// Pool - участники, переданные стратегии (без достигших ограничения нагрузки), в порядке команды
+	Pool []string
+	// MaxReviewers - сколько ревьюверов выбиралось на шаге
+	MaxReviewers int
+	// Weights - веса выбора кандидатов
+	Weights map[string]float64
+	// Preferred - кандидаты с навыками по тегам PR, выбираемые раньше остальных
+	Preferred []string
+	// CursorID - курсор ротации команды перед выбором (round_robin)
+	CursorID string
+	// Loads - количество OPEN PR на ревью у кандидатов перед выбором (least_loaded)
+	Loads map[string]int
+	// RecentReviews - сколько из последних PR автора ревьюил каждый кандидат
+	RecentReviews map[string]int
+}
+
+// ExcludedCandidate - пользователь, исключенный из пула кандидатов
+type ExcludedCandidate struct {
+	UserID string
+	Reason ExclusionReason
+}
+
+// AssignmentReplay - повторный выбор ревьюверов с сохраненным seed на входных данных, сохраненных при назначении.
+// Reproduced = false означает, что изменился сам алгоритм выбора или запись сохранена без входных данных.
+type AssignmentReplay struct {
+	Assignment        *Assignment
+	ReplayedReviewers []string
+	Reproduced        bool
+}
+
+// SelectionSimulation - распределение ревьюверов, выбранных при многократном повторе выбора для гипотетического PR
+type SelectionSimulation struct {
+	// AuthorID - автор гипотетического PR; пустой, если симуляция задана только командой
+	AuthorID   string
+	TeamName   string
+	Strategy   string
+	Iterations int
+	// NoCandidate - количество повторов, в которых не нашлось ни одного ревьювера
+	NoCandidate int
+	// Picks - ревьюверы по убыванию количества выборов
+	Picks []*ReviewerPicks
+}
+
+// ReviewerPicks - сколько раз пользователь был выбран ревьювером в симуляции
+type ReviewerPicks struct {
+	UserID string
+	Count  int
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// CodeOwners - файл CODEOWNERS репозитория
+type CodeOwners struct {
+	Repository string
+	Content    string
+	// Rules заполняется сервисом при разборе Content
+	Rules     []CodeOwnersRule
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// CodeOwnersRule - правило CODEOWNERS: glob-шаблон пути и его владельцы (@u1, @org/team)
+type CodeOwnersRule struct {
+	Pattern string
+	Owners  []string
+}
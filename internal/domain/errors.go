@@ -37,6 +37,18 @@ var (
 		Message: "cannot reassign on merged PR",
 	}
 
+	// ErrPRNotOpen - ревьюверов и решения можно изменять только у OPEN PR
+	ErrPRNotOpen = &DomainError{
+		Code:    "PR_NOT_OPEN",
+		Message: "PR is not open",
+	}
+
+	// ErrInvalidStatusTransition - переход PR в запрошенный статус не допускается
+	ErrInvalidStatusTransition = &DomainError{
+		Code:    "INVALID_STATUS_TRANSITION",
+		Message: "invalid PR status transition",
+	}
+
 	// ErrNotAssigned - ревьювер не назначен на PR
 	ErrNotAssigned = &DomainError{
 		Code:    "NOT_ASSIGNED",
@@ -49,6 +61,30 @@ var (
 		Message: "no active replacement candidate in team",
 	}
 
+	// ErrMandatoryReviewer - обязательного ревьювера команды нельзя заменить без force
+	ErrMandatoryReviewer = &DomainError{
+		Code:    "MANDATORY_REVIEWER",
+		Message: "cannot reassign mandatory reviewer without force",
+	}
+
+	// ErrInvalidReviewer - выбранного пользователя нельзя назначить ревьювером PR
+	ErrInvalidReviewer = &DomainError{
+		Code:    "INVALID_REVIEWER",
+		Message: "user cannot be assigned as reviewer",
+	}
+
+	// ErrReviewerLimit - изменение нарушает ограничения команды на количество ревьюверов PR
+	ErrReviewerLimit = &DomainError{
+		Code:    "REVIEWER_LIMIT",
+		Message: "team reviewer limits violated",
+	}
+
+	// ErrNotApproved - PR не удовлетворяет политике merge команды
+	ErrNotApproved = &DomainError{
+		Code:    "NOT_APPROVED",
+		Message: "PR does not satisfy team merge policy",
+	}
+
 	// ErrNotFound - ресурс не найден
 	ErrNotFound = &DomainError{
 		Code:    "NOT_FOUND",
@@ -56,6 +92,62 @@ var (
 	}
 )
 
+// NewBadRequestError создает ошибку BAD_REQUEST для некорректных входных данных
+func NewBadRequestError(message string) *DomainError {
+	return &DomainError{
+		Code:    "BAD_REQUEST",
+		Message: message,
+	}
+}
+
+// NewNoCandidateError создает ошибку NO_CANDIDATE с причиной, по которой кандидатов не нашлось
+func NewNoCandidateError(reason string) *DomainError {
+	return &DomainError{
+		Code:    "NO_CANDIDATE",
+		Message: fmt.Sprintf("%s: %s", ErrNoCandidate.Message, reason),
+	}
+}
+
+// NewInvalidReviewerError создает ошибку INVALID_REVIEWER с причиной, по которой пользователя нельзя назначить
+func NewInvalidReviewerError(reason string) *DomainError {
+	return &DomainError{
+		Code:    "INVALID_REVIEWER",
+		Message: fmt.Sprintf("%s: %s", ErrInvalidReviewer.Message, reason),
+	}
+}
+
+// NewReviewerLimitError создает ошибку REVIEWER_LIMIT с описанием нарушенного ограничения
+func NewReviewerLimitError(reason string) *DomainError {
+	return &DomainError{
+		Code:    "REVIEWER_LIMIT",
+		Message: fmt.Sprintf("%s: %s", ErrReviewerLimit.Message, reason),
+	}
+}
+
+// NewPRNotOpenError создает ошибку PR_NOT_OPEN с текущим статусом PR
+func NewPRNotOpenError(status Status) *DomainError {
+	return &DomainError{
+		Code:    "PR_NOT_OPEN",
+		Message: fmt.Sprintf("%s: status is %s", ErrPRNotOpen.Message, status),
+	}
+}
+
+// NewInvalidStatusTransitionError создает ошибку INVALID_STATUS_TRANSITION: действие action недопустимо для PR в статусе status
+func NewInvalidStatusTransitionError(status Status, action string) *DomainError {
+	return &DomainError{
+		Code:    "INVALID_STATUS_TRANSITION",
+		Message: fmt.Sprintf("%s: cannot %s PR in status %s", ErrInvalidStatusTransition.Message, action, status),
+	}
+}
+
+// NewNotApprovedError создает ошибку NOT_APPROVED с описанием невыполненного условия политики
+func NewNotApprovedError(reason string) *DomainError {
+	return &DomainError{
+		Code:    "NOT_APPROVED",
+		Message: fmt.Sprintf("%s: %s", ErrNotApproved.Message, reason),
+	}
+}
+
 // NewNotFoundError создает ошибку NOT_FOUND с дополнительным контекстом
 func NewNotFoundError(resource string) *DomainError {
 	return &DomainError{
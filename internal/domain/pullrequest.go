@@ -3,13 +3,59 @@ package domain
 import "time"
 
 type PullRequest struct {
-	ID                string
-	Title             string
-	AuthorID          string
+	ID       string
+	Title    string
+	AuthorID string
+	// CoAuthorIDs - соавторы PR; как и автор, не назначаются ревьюверами
+	CoAuthorIDs       []string
 	Status            Status
 	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          *time.Time
+	// ReviewerTeams - команда, из которой был выбран каждый ревьювер (ID ревьювера -> имя команды)
+	ReviewerTeams map[string]string
+	// ReviewStates - последнее решение каждого назначенного ревьювера (ID ревьювера -> решение);
+	// ревьюверов, еще не отправивших решение, в нем нет
+	ReviewStates map[string]ReviewState
+	// Tags - навыки, требуемые для ревью (например, go, sql); ревьюверы с этими навыками выбираются в первую очередь
+	Tags      []string
+	CreatedAt time.Time
+	MergedAt  *time.Time
+	// ClosedAt - время закрытия без merge; сбрасывается при повторном открытии
+	ClosedAt *time.Time
+}
+
+// AuthorIDs возвращает автора и соавторов PR
+func (pr *PullRequest) AuthorIDs() []string {
+	return append([]string{pr.AuthorID}, pr.CoAuthorIDs...)
+}
+
+// PullRequestFilter - условия выборки списка PR; пустые поля выборку не ограничивают
+type PullRequestFilter struct {
+	Status     Status
+	AuthorID   string
+	ReviewerID string
+	// TeamName - команда автора PR
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Ascending - сначала старые PR; по умолчанию сначала новые
+	Ascending bool
+	// After - позиция последнего PR предыдущей страницы
+	After *PullRequestCursor
+	Limit int
+}
+
+// PullRequestCursor - позиция PR в списке, упорядоченном по времени создания и ID
+type PullRequestCursor struct {
+	// CreatedAt - время создания PR в том виде, в каком оно хранится в БД (дата и время без часового пояса)
+	CreatedAt     time.Time
+	PullRequestID string
+}
+
+// PullRequestPage - страница списка PR
+type PullRequestPage struct {
+	PullRequests []*PullRequest
+	// NextCursor - курсор следующей страницы; пустой, если страница последняя
+	NextCursor string
 }
 
 type PullRequestShort struct {
@@ -19,9 +65,45 @@ type PullRequestShort struct {
 	Status   Status
 }
 
+// ReviewerReplacement - замена ревьювера на PR
+type ReviewerReplacement struct {
+	PullRequestID     string
+	OldReviewerID     string
+	NewReviewerID     string
+	AssignedReviewers []string
+}
+
+// UnreassignedReview - ревью, для которого не нашлось замены; ревьювер остается назначенным на PR
+type UnreassignedReview struct {
+	PullRequestID string
+	ReviewerID    string
+	Reason        string
+}
+
+// BulkDeactivationResult - итог массовой деактивации пользователей
+type BulkDeactivationResult struct {
+	DeactivatedUserIDs []string
+	Replacements       []*ReviewerReplacement
+	Unreassigned       []*UnreassignedReview
+}
+
 type Status string
 
+// IsValid сообщает, является ли status известным статусом PR
+func (status Status) IsValid() bool {
+	switch status {
+	case StatusDraft, StatusOpen, StatusMerged, StatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
 const (
+	// StatusDraft - черновик: ревьюверы не назначаются, пока PR не отмечен готовым к ревью
+	StatusDraft  Status = "DRAFT"
 	StatusOpen   Status = "OPEN"
 	StatusMerged Status = "MERGED"
+	// StatusClosed - PR закрыт без merge; может быть открыт заново
+	StatusClosed Status = "CLOSED"
 )
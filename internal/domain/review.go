@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ReviewState - решение ревьювера по PR
+type ReviewState string
+
+const (
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewState = "COMMENTED"
+	// ReviewPending - ревьювер еще не отправил решение; вручную не задается
+	ReviewPending ReviewState = "PENDING"
+)
+
+// IsValid сообщает, может ли ревьювер отправить решение state
+func (state ReviewState) IsValid() bool {
+	switch state {
+	case ReviewApproved, ReviewChangesRequested, ReviewCommented:
+		return true
+	default:
+		return false
+	}
+}
+
+// Review - решение ревьювера по PR
+type Review struct {
+	ID            int
+	PullRequestID string
+	ReviewerID    string
+	State         ReviewState
+	CreatedAt     time.Time
+}
+
+// MergeOverride - запись журнала о merge PR в обход политики команды
+type MergeOverride struct {
+	ID            int
+	PullRequestID string
+	// Reason - объяснение администратора, почему политика не соблюдена
+	Reason string
+	// Violation - какое условие политики нарушено на момент merge
+	Violation         string
+	Approvals         int
+	RequiredApprovals int
+	CreatedAt         time.Time
+}
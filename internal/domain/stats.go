@@ -6,6 +6,14 @@ type ReviewerStat struct {
 	AssignmentCount int
 }
 
+// AuthorStat - количество PR, в которых пользователь автор или соавтор
+type AuthorStat struct {
+	UserID          string
+	Username        string
+	AuthoredCount   int
+	CoAuthoredCount int
+}
+
 type PRStatusStat struct {
 	Status string
 	Count  int
@@ -2,16 +2,39 @@ package domain
 
 import "time"
 
+// Количество ревьюверов для команд, у которых настройки не заданы явно
+const (
+	DefaultMinReviewers = 1
+	DefaultMaxReviewers = 2
+)
+
 type Team struct {
-	ID        int
-	Name      string
-	Members   []TeamMember
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	ID           int
+	Name         string
+	MinReviewers int
+	MaxReviewers int
+	// FallbackTeams - имена резервных команд в порядке приоритета
+	FallbackTeams []string
+	// MandatoryReviewers - ID пользователей, назначаемых на каждый PR участников команды
+	MandatoryReviewers []string
+	MergePolicy        MergePolicy
+	Members            []TeamMember
+	CreatedAt          time.Time
+	UpdatedAt          *time.Time
 }
 
 type TeamMember struct {
-	UserID   string
-	Username string
-	IsActive bool
+	UserID          string
+	Username        string
+	IsActive        bool
+	SelectionWeight float64
+}
+
+// MergePolicy - условия, при которых PR участников команды можно перевести в MERGED.
+// Нулевое значение merge не ограничивает.
+type MergePolicy struct {
+	// RequiredApprovals - сколько назначенных ревьюверов должны одобрить PR (APPROVED)
+	RequiredApprovals int
+	// BlockOnChangesRequested запрещает merge, пока хотя бы у одного ревьювера последнее решение CHANGES_REQUESTED
+	BlockOnChangesRequested bool
 }
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Unavailability - период отсутствия пользователя (отпуск, больничный).
+// Период действует в интервале [StartsAt, EndsAt).
+type Unavailability struct {
+	ID       int
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+	// ReviewsReassignedAt - момент, когда OPEN PR пользователя были переназначены фоновой задачей
+	ReviewsReassignedAt *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
@@ -2,12 +2,56 @@ package domain
 
 import "time"
 
+// DefaultSelectionWeight - вес пользователя при выборе ревьюверов, если он не задан явно
+const DefaultSelectionWeight = 1.0
+
 type User struct {
-	ID        string
-	Username  string
-	TeamID    int
-	TeamName  string
-	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	ID       string
+	Username string
+	TeamID   int
+	TeamName string
+	IsActive bool
+	// MaxOpenReviews - максимальное количество OPEN PR на ревью, nil - без ограничения
+	MaxOpenReviews *int
+	// Unavailable - пользователь отсутствует в данный момент по расписанию (см. Unavailability)
+	Unavailable bool
+	// Skills - навыки пользователя (например, go, sql, frontend); заполняется только там, где нужен
+	Skills []string
+	// SelectionWeight - относительный вес при случайном выборе ревьюверов из (0, 1]; 0 - не задан
+	SelectionWeight float64
+	CreatedAt       time.Time
+	UpdatedAt       *time.Time
+}
+
+// HasSkills сообщает, покрывают ли навыки пользователя все теги tags
+func (u *User) HasSkills(tags []string) bool {
+	skills := make(map[string]bool, len(u.Skills))
+	for _, skill := range u.Skills {
+		skills[skill] = true
+	}
+	for _, tag := range tags {
+		if !skills[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// EffectiveSelectionWeight возвращает вес пользователя при выборе ревьюверов с учетом значения по умолчанию
+func (u *User) EffectiveSelectionWeight() float64 {
+	if u.SelectionWeight <= 0 {
+		return DefaultSelectionWeight
+	}
+	return u.SelectionWeight
+}
+
+// ReviewLoad - текущая нагрузка ревьювера относительно его ограничения
+type ReviewLoad struct {
+	OpenReviews    int
+	MaxOpenReviews *int
+}
+
+// AtCapacity сообщает, достиг ли пользователь своего ограничения на OPEN PR
+func (u *User) AtCapacity(openReviews int) bool {
+	return u.MaxOpenReviews != nil && openReviews >= *u.MaxOpenReviews
 }
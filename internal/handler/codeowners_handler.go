@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+func (h *Handler) SetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var req SetCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	codeOwners, err := h.codeOwnersService.SetCodeOwners(r.Context(), req.Repository, req.Content)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(CodeOwnersItemResponse{
+		CodeOwners: domainCodeOwnersToHTTP(codeOwners),
+	})
+}
+
+func (h *Handler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	repository := r.URL.Query().Get("repository")
+	if repository == "" {
+		h.handleError(w, &domain.DomainError{
+			Code:    "BAD_REQUEST",
+			Message: "repository parameter is required",
+		})
+		return
+	}
+
+	codeOwners, err := h.codeOwnersService.GetCodeOwners(r.Context(), repository)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(CodeOwnersItemResponse{
+		CodeOwners: domainCodeOwnersToHTTP(codeOwners),
+	})
+}
@@ -35,9 +35,9 @@ func (h *Handler) handleError(w http.ResponseWriter, err error) {
 
 func getStatusCode(errorCode string) int {
 	switch errorCode {
-	case "TEAM_EXISTS":
+	case "TEAM_EXISTS", "BAD_REQUEST":
 		return http.StatusBadRequest
-	case "PR_EXISTS", "PR_MERGED", "NOT_ASSIGNED", "NO_CANDIDATE":
+	case "PR_EXISTS", "PR_MERGED", "NOT_ASSIGNED", "NO_CANDIDATE", "MANDATORY_REVIEWER", "INVALID_REVIEWER", "REVIEWER_LIMIT", "NOT_APPROVED", "PR_NOT_OPEN", "INVALID_STATUS_TRANSITION":
 		return http.StatusConflict
 	case "NOT_FOUND":
 		return http.StatusNotFound
@@ -3,10 +3,12 @@ package handler
 import "github.com/bagdasarian/avito-pr-reviewer/internal/service"
 
 type Handler struct {
-	teamService        service.TeamService
-	userService        service.UserService
-	pullRequestService service.PullRequestService
-	statsService       service.StatsService
+	teamService           service.TeamService
+	userService           service.UserService
+	pullRequestService    service.PullRequestService
+	statsService          service.StatsService
+	unavailabilityService service.UnavailabilityService
+	codeOwnersService     service.CodeOwnersService
 }
 
 func NewHandler(
@@ -14,11 +16,15 @@ func NewHandler(
 	userService service.UserService,
 	pullRequestService service.PullRequestService,
 	statsService service.StatsService,
+	unavailabilityService service.UnavailabilityService,
+	codeOwnersService service.CodeOwnersService,
 ) *Handler {
 	return &Handler{
-		teamService:        teamService,
-		userService:        userService,
-		pullRequestService: pullRequestService,
-		statsService:       statsService,
+		teamService:           teamService,
+		userService:           userService,
+		pullRequestService:    pullRequestService,
+		statsService:          statsService,
+		unavailabilityService: unavailabilityService,
+		codeOwnersService:     codeOwnersService,
 	}
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
@@ -10,15 +11,33 @@ func domainTeamToHTTP(team *domain.Team) TeamResponse {
 	members := make([]TeamMemberResponse, 0, len(team.Members))
 	for _, member := range team.Members {
 		members = append(members, TeamMemberResponse{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+			UserID:          member.UserID,
+			Username:        member.Username,
+			IsActive:        member.IsActive,
+			SelectionWeight: member.SelectionWeight,
 		})
 	}
 
+	fallbackTeams := team.FallbackTeams
+	if fallbackTeams == nil {
+		fallbackTeams = []string{}
+	}
+	mandatoryReviewers := team.MandatoryReviewers
+	if mandatoryReviewers == nil {
+		mandatoryReviewers = []string{}
+	}
+
 	return TeamResponse{
-		TeamName: team.Name,
-		Members:  members,
+		TeamName:           team.Name,
+		Members:            members,
+		MinReviewers:       team.MinReviewers,
+		MaxReviewers:       team.MaxReviewers,
+		FallbackTeams:      fallbackTeams,
+		MandatoryReviewers: mandatoryReviewers,
+		MergePolicy: MergePolicyResponse{
+			RequiredApprovals:       team.MergePolicy.RequiredApprovals,
+			BlockOnChangesRequested: team.MergePolicy.BlockOnChangesRequested,
+		},
 	}
 }
 
@@ -32,23 +51,37 @@ func httpTeamToDomain(req TeamRequest) *domain.Team {
 		})
 	}
 
+	minReviewers := domain.DefaultMinReviewers
+	if req.MinReviewers != nil {
+		minReviewers = *req.MinReviewers
+	}
+	maxReviewers := domain.DefaultMaxReviewers
+	if req.MaxReviewers != nil {
+		maxReviewers = *req.MaxReviewers
+	}
+
 	return &domain.Team{
-		Name:    req.TeamName,
-		Members: members,
+		Name:         req.TeamName,
+		Members:      members,
+		MinReviewers: minReviewers,
+		MaxReviewers: maxReviewers,
 	}
 }
 
 func domainUserToHTTP(user *domain.User) UserResponse {
 	return UserResponse{
-		UserID:   user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		UserID:          user.ID,
+		Username:        user.Username,
+		TeamName:        user.TeamName,
+		IsActive:        user.IsActive,
+		MaxOpenReviews:  user.MaxOpenReviews,
+		SelectionWeight: user.EffectiveSelectionWeight(),
+		Skills:          user.Skills,
 	}
 }
 
 func domainPRToHTTP(pr *domain.PullRequest) PullRequestResponse {
-	var createdAt, mergedAt *string
+	var createdAt, mergedAt, closedAt *string
 	if !pr.CreatedAt.IsZero() {
 		createdAtStr := pr.CreatedAt.Format(time.RFC3339)
 		createdAt = &createdAtStr
@@ -57,18 +90,105 @@ func domainPRToHTTP(pr *domain.PullRequest) PullRequestResponse {
 		mergedAtStr := pr.MergedAt.Format(time.RFC3339)
 		mergedAt = &mergedAtStr
 	}
+	if pr.ClosedAt != nil {
+		closedAtStr := pr.ClosedAt.Format(time.RFC3339)
+		closedAt = &closedAtStr
+	}
+
+	var reviewStates map[string]string
+	if len(pr.AssignedReviewers) > 0 {
+		reviewStates = make(map[string]string, len(pr.AssignedReviewers))
+		for _, reviewerID := range pr.AssignedReviewers {
+			state, ok := pr.ReviewStates[reviewerID]
+			if !ok {
+				state = domain.ReviewPending
+			}
+			reviewStates[reviewerID] = string(state)
+		}
+	}
 
 	return PullRequestResponse{
 		PullRequestID:     pr.ID,
 		PullRequestName:   pr.Title,
 		AuthorID:          pr.AuthorID,
+		CoAuthorIDs:       pr.CoAuthorIDs,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewers,
+		ReviewerTeams:     pr.ReviewerTeams,
+		ReviewStates:      reviewStates,
+		Tags:              pr.Tags,
 		CreatedAt:         createdAt,
 		MergedAt:          mergedAt,
+		ClosedAt:          closedAt,
 	}
 }
 
+func domainAssignmentReplaysToHTTP(replays []*domain.AssignmentReplay) []AssignmentResponse {
+	result := make([]AssignmentResponse, 0, len(replays))
+	for _, replay := range replays {
+		assignment := replay.Assignment
+		result = append(result, AssignmentResponse{
+			Event:              string(assignment.Event),
+			Seed:               strconv.FormatInt(assignment.Seed, 10),
+			ReplacedReviewerID: assignment.ReplacedReviewerID,
+			PreviousReviewers:  assignment.PreviousReviewers,
+			Repository:         assignment.Repository,
+			ChangedFiles:       assignment.ChangedFiles,
+			SelectedReviewers:  assignment.SelectedReviewers,
+			ReplayedReviewers:  replay.ReplayedReviewers,
+			Reproduced:         replay.Reproduced,
+			CreatedAt:          assignment.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+func domainAssignmentsToHTTP(assignments []*domain.Assignment) []AssignmentTraceResponse {
+	result := make([]AssignmentTraceResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		steps := make([]SelectionStepResponse, 0, len(assignment.Steps))
+		for _, step := range assignment.Steps {
+			excluded := make([]ExcludedCandidateResponse, 0, len(step.Excluded))
+			for _, candidate := range step.Excluded {
+				excluded = append(excluded, ExcludedCandidateResponse{
+					UserID: candidate.UserID,
+					Reason: string(candidate.Reason),
+				})
+			}
+			var inputs *SelectionInputsResponse
+			if step.Inputs != nil {
+				inputs = &SelectionInputsResponse{
+					Pool:          step.Inputs.Pool,
+					MaxReviewers:  step.Inputs.MaxReviewers,
+					Weights:       step.Inputs.Weights,
+					Preferred:     step.Inputs.Preferred,
+					CursorID:      step.Inputs.CursorID,
+					Loads:         step.Inputs.Loads,
+					RecentReviews: step.Inputs.RecentReviews,
+				}
+			}
+			steps = append(steps, SelectionStepResponse{
+				Source:     string(step.Source),
+				TeamName:   step.TeamName,
+				Strategy:   step.Strategy,
+				Candidates: step.Candidates,
+				Excluded:   excluded,
+				Selected:   step.Selected,
+				Inputs:     inputs,
+			})
+		}
+
+		result = append(result, AssignmentTraceResponse{
+			Event:              string(assignment.Event),
+			ReplacedReviewerID: assignment.ReplacedReviewerID,
+			SelectedReviewers:  assignment.SelectedReviewers,
+			Steps:              steps,
+			CreatedAt:          assignment.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
 func domainPRShortToHTTP(pr *domain.PullRequestShort) PullRequestShortResponse {
 	return PullRequestShortResponse{
 		PullRequestID:   pr.ID,
@@ -85,3 +205,98 @@ func domainPRShortsToHTTP(prs []*domain.PullRequestShort) []PullRequestShortResp
 	}
 	return result
 }
+
+func domainUnavailabilityToHTTP(unavailability *domain.Unavailability) UnavailabilityResponse {
+	var reviewsReassignedAt *string
+	if unavailability.ReviewsReassignedAt != nil {
+		reviewsReassignedAtStr := unavailability.ReviewsReassignedAt.Format(time.RFC3339)
+		reviewsReassignedAt = &reviewsReassignedAtStr
+	}
+
+	return UnavailabilityResponse{
+		ID:                  unavailability.ID,
+		UserID:              unavailability.UserID,
+		StartsAt:            unavailability.StartsAt.Format(time.RFC3339),
+		EndsAt:              unavailability.EndsAt.Format(time.RFC3339),
+		Reason:              unavailability.Reason,
+		ReviewsReassignedAt: reviewsReassignedAt,
+	}
+}
+
+func domainUnavailabilitiesToHTTP(unavailabilities []*domain.Unavailability) []UnavailabilityResponse {
+	result := make([]UnavailabilityResponse, 0, len(unavailabilities))
+	for _, unavailability := range unavailabilities {
+		result = append(result, domainUnavailabilityToHTTP(unavailability))
+	}
+	return result
+}
+
+func domainReplacementsToHTTP(replacements []*domain.ReviewerReplacement) []ReviewerReplacementResponse {
+	result := make([]ReviewerReplacementResponse, 0, len(replacements))
+	for _, replacement := range replacements {
+		result = append(result, ReviewerReplacementResponse{
+			PullRequestID:     replacement.PullRequestID,
+			ReplacedBy:        replacement.NewReviewerID,
+			AssignedReviewers: replacement.AssignedReviewers,
+		})
+	}
+	return result
+}
+
+func domainBulkDeactivationToHTTP(result *domain.BulkDeactivationResult) BulkDeactivateResponse {
+	unreassigned := make([]UnreassignedReviewResponse, 0, len(result.Unreassigned))
+	for _, review := range result.Unreassigned {
+		unreassigned = append(unreassigned, UnreassignedReviewResponse{
+			PullRequestID: review.PullRequestID,
+			ReviewerID:    review.ReviewerID,
+			Reason:        review.Reason,
+		})
+	}
+
+	return BulkDeactivateResponse{
+		DeactivatedUserIDs: result.DeactivatedUserIDs,
+		ReassignedPRs:      domainReplacementsToHTTP(result.Replacements),
+		UnreassignedPRs:    unreassigned,
+	}
+}
+
+func domainCodeOwnersToHTTP(codeOwners *domain.CodeOwners) CodeOwnersResponse {
+	updatedAt := codeOwners.CreatedAt
+	if codeOwners.UpdatedAt != nil {
+		updatedAt = *codeOwners.UpdatedAt
+	}
+
+	rules := make([]CodeOwnersRuleResponse, 0, len(codeOwners.Rules))
+	for _, rule := range codeOwners.Rules {
+		rules = append(rules, CodeOwnersRuleResponse{
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		})
+	}
+
+	return CodeOwnersResponse{
+		Repository: codeOwners.Repository,
+		Rules:      rules,
+		UpdatedAt:  updatedAt.Format(time.RFC3339),
+	}
+}
+
+func domainSimulationToHTTP(simulation *domain.SelectionSimulation) SimulateSelectionResponse {
+	picks := make([]ReviewerPicksResponse, 0, len(simulation.Picks))
+	for _, pick := range simulation.Picks {
+		picks = append(picks, ReviewerPicksResponse{
+			UserID: pick.UserID,
+			Count:  pick.Count,
+			Share:  float64(pick.Count) / float64(simulation.Iterations),
+		})
+	}
+
+	return SimulateSelectionResponse{
+		AuthorID:    simulation.AuthorID,
+		TeamName:    simulation.TeamName,
+		Strategy:    simulation.Strategy,
+		Iterations:  simulation.Iterations,
+		NoCandidate: simulation.NoCandidate,
+		Picks:       picks,
+	}
+}
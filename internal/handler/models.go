@@ -1,5 +1,7 @@
 package handler
 
+import "time"
+
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
@@ -16,72 +18,273 @@ type TeamMemberRequest struct {
 }
 
 type TeamRequest struct {
-	TeamName string              `json:"team_name"`
-	Members  []TeamMemberRequest `json:"members"`
+	TeamName     string              `json:"team_name"`
+	Members      []TeamMemberRequest `json:"members"`
+	MinReviewers *int                `json:"min_reviewers,omitempty"`
+	MaxReviewers *int                `json:"max_reviewers,omitempty"`
 }
 
 type TeamMemberResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID          string  `json:"user_id"`
+	Username        string  `json:"username"`
+	IsActive        bool    `json:"is_active"`
+	SelectionWeight float64 `json:"selection_weight"`
 }
 
 type TeamResponse struct {
-	TeamName string               `json:"team_name"`
-	Members  []TeamMemberResponse `json:"members"`
+	TeamName           string               `json:"team_name"`
+	Members            []TeamMemberResponse `json:"members"`
+	MinReviewers       int                  `json:"min_reviewers"`
+	MaxReviewers       int                  `json:"max_reviewers"`
+	FallbackTeams      []string             `json:"fallback_teams"`
+	MandatoryReviewers []string             `json:"mandatory_reviewers"`
+	MergePolicy        MergePolicyResponse  `json:"merge_policy"`
+}
+
+type MergePolicyResponse struct {
+	RequiredApprovals       int  `json:"required_approvals"`
+	BlockOnChangesRequested bool `json:"block_on_changes_requested"`
 }
 
 type CreateTeamResponse struct {
 	Team TeamResponse `json:"team"`
 }
 
+type TeamSettingsRequest struct {
+	TeamName     string `json:"team_name"`
+	MinReviewers int    `json:"min_reviewers"`
+	MaxReviewers int    `json:"max_reviewers"`
+}
+
+type TeamSettingsResponse struct {
+	Team TeamResponse `json:"team"`
+}
+
+type SetFallbackTeamsRequest struct {
+	TeamName      string   `json:"team_name"`
+	FallbackTeams []string `json:"fallback_teams"`
+}
+
+type SetFallbackTeamsResponse struct {
+	Team TeamResponse `json:"team"`
+}
+
+type SetMandatoryReviewersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type SetMandatoryReviewersResponse struct {
+	Team TeamResponse `json:"team"`
+}
+
+type SetMergePolicyRequest struct {
+	TeamName                string `json:"team_name"`
+	RequiredApprovals       int    `json:"required_approvals"`
+	BlockOnChangesRequested bool   `json:"block_on_changes_requested"`
+}
+
+type SetMergePolicyResponse struct {
+	Team TeamResponse `json:"team"`
+}
+
 type SetIsActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	UserID              string `json:"user_id"`
+	IsActive            bool   `json:"is_active"`
+	ReassignOpenReviews bool   `json:"reassign_open_reviews"`
 }
 
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID          string   `json:"user_id"`
+	Username        string   `json:"username"`
+	TeamName        string   `json:"team_name"`
+	IsActive        bool     `json:"is_active"`
+	MaxOpenReviews  *int     `json:"max_open_reviews,omitempty"`
+	SelectionWeight float64  `json:"selection_weight"`
+	Skills          []string `json:"skills,omitempty"`
+}
+
+type ReviewerReplacementResponse struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	ReplacedBy        string   `json:"replaced_by"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
 }
 
 type SetIsActiveResponse struct {
+	User          UserResponse                  `json:"user"`
+	ReassignedPRs []ReviewerReplacementResponse `json:"reassigned_prs,omitempty"`
+}
+
+type BulkDeactivateRequest struct {
+	TeamName string   `json:"team_name,omitempty"`
+	UserIDs  []string `json:"user_ids,omitempty"`
+}
+
+type UnreassignedReviewResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Reason        string `json:"reason"`
+}
+
+type BulkDeactivateResponse struct {
+	DeactivatedUserIDs []string                      `json:"deactivated_user_ids"`
+	ReassignedPRs      []ReviewerReplacementResponse `json:"reassigned_prs"`
+	UnreassignedPRs    []UnreassignedReviewResponse  `json:"unreassigned_prs"`
+}
+
+type SetSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+type SetSkillsResponse struct {
+	User UserResponse `json:"user"`
+}
+
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type SetMaxOpenReviewsResponse struct {
+	User UserResponse `json:"user"`
+}
+
+type SetSelectionWeightRequest struct {
+	UserID          string  `json:"user_id"`
+	SelectionWeight float64 `json:"selection_weight"`
+}
+
+type SetSelectionWeightResponse struct {
 	User UserResponse `json:"user"`
 }
 
+type UnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type UpdateUnavailabilityRequest struct {
+	ID       int       `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type DeleteUnavailabilityRequest struct {
+	ID int `json:"id"`
+}
+
+type UnavailabilityResponse struct {
+	ID                  int     `json:"id"`
+	UserID              string  `json:"user_id"`
+	StartsAt            string  `json:"starts_at"`
+	EndsAt              string  `json:"ends_at"`
+	Reason              string  `json:"reason"`
+	ReviewsReassignedAt *string `json:"reviews_reassigned_at,omitempty"`
+}
+
+type UnavailabilityItemResponse struct {
+	Unavailability UnavailabilityResponse `json:"unavailability"`
+}
+
+type GetUnavailabilityResponse struct {
+	UserID         string                   `json:"user_id"`
+	Unavailability []UnavailabilityResponse `json:"unavailability"`
+}
+
+type DeleteUnavailabilityResponse struct {
+	ID int `json:"id"`
+}
+
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	CoAuthorIDs     []string `json:"co_author_ids,omitempty"`
+	Repository      string   `json:"repository,omitempty"`
+	ChangedFiles    []string `json:"changed_files,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	// Draft создает черновик без ревьюверов; repository и changed_files для него передаются в markReady
+	Draft bool `json:"draft"`
 }
 
 type PullRequestResponse struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	CreatedAt         *string  `json:"createdAt,omitempty"`
-	MergedAt          *string  `json:"mergedAt,omitempty"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	CoAuthorIDs       []string          `json:"co_author_ids,omitempty"`
+	Status            string            `json:"status"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	ReviewerTeams     map[string]string `json:"reviewer_teams,omitempty"`
+	// ReviewStates - последнее решение каждого назначенного ревьювера; PENDING, если решения еще нет
+	ReviewStates map[string]string `json:"review_states,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	CreatedAt    *string           `json:"createdAt,omitempty"`
+	MergedAt     *string           `json:"mergedAt,omitempty"`
+	ClosedAt     *string           `json:"closedAt,omitempty"`
 }
 
 type CreatePRResponse struct {
 	PR PullRequestResponse `json:"pr"`
 }
 
+type GetPRResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type ListPRsResponse struct {
+	PullRequests []PullRequestResponse `json:"pull_requests"`
+	// NextCursor передается в параметре cursor для получения следующей страницы; отсутствует на последней
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	// AdminOverride разрешает merge в обход политики команды; OverrideReason обязателен и сохраняется в журнале
+	AdminOverride  bool   `json:"admin_override"`
+	OverrideReason string `json:"override_reason,omitempty"`
 }
 
 type MergePRResponse struct {
 	PR PullRequestResponse `json:"pr"`
 }
 
+type MarkReadyRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Repository    string   `json:"repository,omitempty"`
+	ChangedFiles  []string `json:"changed_files,omitempty"`
+}
+
+type MarkReadyResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type ClosePRRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ClosePRResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type ReopenPRRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ReopenPRResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	// NewUserID - необязательный явно выбранный новый ревьювер; если не задан, замена выбирается стратегией
+	NewUserID string `json:"new_user_id,omitempty"`
+	// Force разрешает заменить обязательного ревьювера команды
+	Force bool `json:"force"`
 }
 
 type ReassignReviewerResponse struct {
@@ -89,6 +292,120 @@ type ReassignReviewerResponse struct {
 	ReplacedBy string              `json:"replaced_by"`
 }
 
+type AddReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+type AddReviewerResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type RemoveReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	// Force разрешает снять обязательного ревьювера команды
+	Force bool `json:"force"`
+}
+
+type RemoveReviewerResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	State         string `json:"state"`
+}
+
+type SubmitReviewResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+// AssignmentResponse - один выбор ревьюверов на PR и результат его повтора.
+// Seed передается строкой, чтобы клиенты на JavaScript не теряли точность int64.
+type AssignmentResponse struct {
+	Event              string   `json:"event"`
+	Seed               string   `json:"seed"`
+	ReplacedReviewerID string   `json:"replaced_reviewer_id,omitempty"`
+	PreviousReviewers  []string `json:"previous_reviewers,omitempty"`
+	Repository         string   `json:"repository,omitempty"`
+	ChangedFiles       []string `json:"changed_files,omitempty"`
+	SelectedReviewers  []string `json:"selected_reviewers"`
+	ReplayedReviewers  []string `json:"replayed_reviewers"`
+	Reproduced         bool     `json:"reproduced"`
+	CreatedAt          string   `json:"created_at"`
+}
+
+type ExplainAssignmentResponse struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Assignments   []AssignmentResponse `json:"assignments"`
+}
+
+type ExcludedCandidateResponse struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type SelectionStepResponse struct {
+	Source     string                      `json:"source"`
+	TeamName   string                      `json:"team_name"`
+	Strategy   string                      `json:"strategy"`
+	Candidates []string                    `json:"candidates"`
+	Excluded   []ExcludedCandidateResponse `json:"excluded"`
+	Selected   []string                    `json:"selected"`
+	Inputs     *SelectionInputsResponse    `json:"inputs,omitempty"`
+}
+
+// SelectionInputsResponse - входные данные стратегии на шаге выбора, по которым выбор повторяется
+type SelectionInputsResponse struct {
+	Pool          []string           `json:"pool"`
+	MaxReviewers  int                `json:"max_reviewers"`
+	Weights       map[string]float64 `json:"weights"`
+	Preferred     []string           `json:"preferred,omitempty"`
+	CursorID      string             `json:"cursor_id,omitempty"`
+	Loads         map[string]int     `json:"loads,omitempty"`
+	RecentReviews map[string]int     `json:"recent_reviews,omitempty"`
+}
+
+type AssignmentTraceResponse struct {
+	Event              string                  `json:"event"`
+	ReplacedReviewerID string                  `json:"replaced_reviewer_id,omitempty"`
+	SelectedReviewers  []string                `json:"selected_reviewers"`
+	Steps              []SelectionStepResponse `json:"steps"`
+	CreatedAt          string                  `json:"created_at"`
+}
+
+type GetAssignmentResponse struct {
+	PullRequestID string                    `json:"pull_request_id"`
+	Assignments   []AssignmentTraceResponse `json:"assignments"`
+}
+
+type SimulateSelectionRequest struct {
+	AuthorID     string   `json:"author_id,omitempty"`
+	TeamName     string   `json:"team_name,omitempty"`
+	Iterations   int      `json:"iterations,omitempty"`
+	Repository   string   `json:"repository,omitempty"`
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// ReviewerPicksResponse - сколько раз пользователь был выбран и доля повторов, в которых он выбран
+type ReviewerPicksResponse struct {
+	UserID string  `json:"user_id"`
+	Count  int     `json:"count"`
+	Share  float64 `json:"share"`
+}
+
+type SimulateSelectionResponse struct {
+	AuthorID    string                  `json:"author_id,omitempty"`
+	TeamName    string                  `json:"team_name"`
+	Strategy    string                  `json:"strategy"`
+	Iterations  int                     `json:"iterations"`
+	NoCandidate int                     `json:"no_candidate"`
+	Picks       []ReviewerPicksResponse `json:"picks"`
+}
+
 type PullRequestShortResponse struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
@@ -97,8 +414,10 @@ type PullRequestShortResponse struct {
 }
 
 type GetReviewPRsResponse struct {
-	UserID       string                     `json:"user_id"`
-	PullRequests []PullRequestShortResponse `json:"pull_requests"`
+	UserID         string                     `json:"user_id"`
+	PullRequests   []PullRequestShortResponse `json:"pull_requests"`
+	OpenReviews    int                        `json:"open_reviews"`
+	MaxOpenReviews *int                       `json:"max_open_reviews"`
 }
 
 type ReviewerStatResponse struct {
@@ -107,6 +426,15 @@ type ReviewerStatResponse struct {
 	AssignmentCount int    `json:"assignment_count"`
 }
 
+// AuthorStatResponse - статистика авторства; pr_count включает PR, где пользователь соавтор
+type AuthorStatResponse struct {
+	UserID          string `json:"user_id"`
+	Username        string `json:"username"`
+	PRCount         int    `json:"pr_count"`
+	AuthoredCount   int    `json:"authored_count"`
+	CoAuthoredCount int    `json:"co_authored_count"`
+}
+
 type PRStatusStatResponse struct {
 	Status string `json:"status"`
 	Count  int    `json:"count"`
@@ -114,5 +442,26 @@ type PRStatusStatResponse struct {
 
 type StatsResponse struct {
 	ReviewerStats []ReviewerStatResponse `json:"reviewer_stats"`
+	AuthorStats   []AuthorStatResponse   `json:"author_stats"`
 	PRStats       []PRStatusStatResponse `json:"pr_stats"`
 }
+
+type SetCodeOwnersRequest struct {
+	Repository string `json:"repository"`
+	Content    string `json:"content"`
+}
+
+type CodeOwnersRuleResponse struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type CodeOwnersResponse struct {
+	Repository string                   `json:"repository"`
+	Rules      []CodeOwnersRuleResponse `json:"rules"`
+	UpdatedAt  string                   `json:"updated_at"`
+}
+
+type CodeOwnersItemResponse struct {
+	CodeOwners CodeOwnersResponse `json:"codeowners"`
+}
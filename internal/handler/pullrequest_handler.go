@@ -3,6 +3,11 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/service"
 )
 
 func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
@@ -12,7 +17,16 @@ func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.pullRequestService.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.pullRequestService.CreatePR(r.Context(), service.CreatePRInput{
+		PullRequestID: req.PullRequestID,
+		Title:         req.PullRequestName,
+		AuthorID:      req.AuthorID,
+		CoAuthorIDs:   req.CoAuthorIDs,
+		Repository:    req.Repository,
+		ChangedFiles:  req.ChangedFiles,
+		Tags:          req.Tags,
+		Draft:         req.Draft,
+	})
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -25,6 +39,87 @@ func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.handleError(w, &domain.DomainError{
+			Code:    "BAD_REQUEST",
+			Message: "pull_request_id parameter is required",
+		})
+		return
+	}
+
+	pr, err := h.pullRequestService.GetPR(r.Context(), prID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(GetPRResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
+func (h *Handler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := service.ListPRsInput{
+		Status:     domain.Status(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		ReviewerID: query.Get("reviewer_id"),
+		TeamName:   query.Get("team_name"),
+		Order:      query.Get("order"),
+		Cursor:     query.Get("cursor"),
+	}
+
+	var err error
+	if input.CreatedFrom, err = parseTimeParam(query.Get("created_from")); err != nil {
+		h.handleError(w, domain.NewBadRequestError("created_from must be in RFC3339 format"))
+		return
+	}
+	if input.CreatedTo, err = parseTimeParam(query.Get("created_to")); err != nil {
+		h.handleError(w, domain.NewBadRequestError("created_to must be in RFC3339 format"))
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if input.Limit, err = strconv.Atoi(limit); err != nil {
+			h.handleError(w, domain.NewBadRequestError("limit must be an integer"))
+			return
+		}
+	}
+
+	page, err := h.pullRequestService.ListPRs(r.Context(), input)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response := ListPRsResponse{
+		PullRequests: make([]PullRequestResponse, len(page.PullRequests)),
+		NextCursor:   page.NextCursor,
+	}
+	for i, pr := range page.PullRequests {
+		response.PullRequests[i] = domainPRToHTTP(pr)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseTimeParam разбирает необязательный параметр запроса в формате RFC3339
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
 	var req MergePRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -32,7 +127,7 @@ func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.pullRequestService.MergePR(r.Context(), req.PullRequestID)
+	pr, err := h.pullRequestService.MergePR(r.Context(), req.PullRequestID, req.AdminOverride, req.OverrideReason)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -45,6 +140,66 @@ func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	var req MarkReadyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	pr, err := h.pullRequestService.MarkReady(r.Context(), req.PullRequestID, req.Repository, req.ChangedFiles)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(MarkReadyResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
+func (h *Handler) ClosePR(w http.ResponseWriter, r *http.Request) {
+	var req ClosePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	pr, err := h.pullRequestService.ClosePR(r.Context(), req.PullRequestID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ClosePRResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
+func (h *Handler) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	var req ReopenPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	pr, err := h.pullRequestService.ReopenPR(r.Context(), req.PullRequestID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ReopenPRResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
 func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	var req ReassignReviewerRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -52,7 +207,15 @@ func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, newReviewerID, err := h.pullRequestService.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	var pr *domain.PullRequest
+	var newReviewerID string
+	var err error
+	if req.NewUserID != "" {
+		newReviewerID = req.NewUserID
+		pr, err = h.pullRequestService.ReassignReviewerTo(r.Context(), req.PullRequestID, req.OldUserID, req.NewUserID, req.Force)
+	} else {
+		pr, newReviewerID, err = h.pullRequestService.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID, req.Force)
+	}
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -65,3 +228,136 @@ func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		ReplacedBy: newReviewerID,
 	})
 }
+
+func (h *Handler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req AddReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	pr, err := h.pullRequestService.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(AddReviewerResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
+func (h *Handler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req RemoveReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	pr, err := h.pullRequestService.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID, req.Force)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(RemoveReviewerResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
+func (h *Handler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	pr, err := h.pullRequestService.SubmitReview(r.Context(), req.PullRequestID, req.UserID, domain.ReviewState(req.State))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SubmitReviewResponse{
+		PR: domainPRToHTTP(pr),
+	})
+}
+
+func (h *Handler) ExplainAssignment(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.handleError(w, &domain.DomainError{
+			Code:    "BAD_REQUEST",
+			Message: "pull_request_id parameter is required",
+		})
+		return
+	}
+
+	replays, err := h.pullRequestService.ExplainAssignment(r.Context(), prID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ExplainAssignmentResponse{
+		PullRequestID: prID,
+		Assignments:   domainAssignmentReplaysToHTTP(replays),
+	})
+}
+
+func (h *Handler) GetAssignment(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.handleError(w, &domain.DomainError{
+			Code:    "BAD_REQUEST",
+			Message: "pull_request_id parameter is required",
+		})
+		return
+	}
+
+	assignments, err := h.pullRequestService.GetAssignments(r.Context(), prID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(GetAssignmentResponse{
+		PullRequestID: prID,
+		Assignments:   domainAssignmentsToHTTP(assignments),
+	})
+}
+
+func (h *Handler) SimulateSelection(w http.ResponseWriter, r *http.Request) {
+	var req SimulateSelectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	simulation, err := h.pullRequestService.SimulateSelection(r.Context(), service.SimulationInput{
+		AuthorID:     req.AuthorID,
+		TeamName:     req.TeamName,
+		Iterations:   req.Iterations,
+		Repository:   req.Repository,
+		ChangedFiles: req.ChangedFiles,
+		Tags:         req.Tags,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domainSimulationToHTTP(simulation))
+}
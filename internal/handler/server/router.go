@@ -9,10 +9,35 @@ import (
 func SetupRoutes(mux *http.ServeMux, h *handler.Handler) {
 	mux.HandleFunc("POST /team/add", h.CreateTeam)
 	mux.HandleFunc("GET /team/get", h.GetTeam)
+	mux.HandleFunc("POST /team/setSettings", h.SetTeamSettings)
+	mux.HandleFunc("POST /team/setFallbacks", h.SetFallbackTeams)
+	mux.HandleFunc("POST /team/setMandatoryReviewers", h.SetMandatoryReviewers)
+	mux.HandleFunc("POST /team/setMergePolicy", h.SetMergePolicy)
 	mux.HandleFunc("POST /users/setIsActive", h.SetIsActive)
+	mux.HandleFunc("POST /users/bulkDeactivate", h.BulkDeactivate)
+	mux.HandleFunc("POST /users/setMaxOpenReviews", h.SetMaxOpenReviews)
+	mux.HandleFunc("POST /users/setSelectionWeight", h.SetSelectionWeight)
+	mux.HandleFunc("POST /users/setSkills", h.SetSkills)
 	mux.HandleFunc("GET /users/getReview", h.GetReviewPRs)
+	mux.HandleFunc("POST /users/addUnavailability", h.AddUnavailability)
+	mux.HandleFunc("GET /users/getUnavailability", h.GetUnavailability)
+	mux.HandleFunc("POST /users/updateUnavailability", h.UpdateUnavailability)
+	mux.HandleFunc("POST /users/deleteUnavailability", h.DeleteUnavailability)
+	mux.HandleFunc("POST /codeowners/set", h.SetCodeOwners)
+	mux.HandleFunc("GET /codeowners/get", h.GetCodeOwners)
 	mux.HandleFunc("POST /pullRequest/create", h.CreatePR)
+	mux.HandleFunc("GET /pullRequest/get", h.GetPR)
+	mux.HandleFunc("GET /pullRequest/list", h.ListPRs)
 	mux.HandleFunc("POST /pullRequest/merge", h.MergePR)
+	mux.HandleFunc("POST /pullRequest/markReady", h.MarkReady)
+	mux.HandleFunc("POST /pullRequest/close", h.ClosePR)
+	mux.HandleFunc("POST /pullRequest/reopen", h.ReopenPR)
 	mux.HandleFunc("POST /pullRequest/reassign", h.ReassignReviewer)
+	mux.HandleFunc("POST /pullRequest/addReviewer", h.AddReviewer)
+	mux.HandleFunc("POST /pullRequest/removeReviewer", h.RemoveReviewer)
+	mux.HandleFunc("POST /pullRequest/review", h.SubmitReview)
+	mux.HandleFunc("GET /pullRequest/assignment", h.GetAssignment)
+	mux.HandleFunc("GET /pullRequest/explainAssignment", h.ExplainAssignment)
+	mux.HandleFunc("POST /pullRequest/simulate", h.SimulateSelection)
 	mux.HandleFunc("GET /stats", h.GetStats)
 }
@@ -12,6 +12,12 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	authorStats, err := h.statsService.GetAuthorStats(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
 	prStats, err := h.statsService.GetPRStatsByStatus(r.Context())
 	if err != nil {
 		h.handleError(w, err)
@@ -20,6 +26,7 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 
 	response := StatsResponse{
 		ReviewerStats: make([]ReviewerStatResponse, len(reviewerStats)),
+		AuthorStats:   make([]AuthorStatResponse, len(authorStats)),
 		PRStats:       make([]PRStatusStatResponse, len(prStats)),
 	}
 
@@ -31,6 +38,16 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	for i, stat := range authorStats {
+		response.AuthorStats[i] = AuthorStatResponse{
+			UserID:          stat.UserID,
+			Username:        stat.Username,
+			PRCount:         stat.AuthoredCount + stat.CoAuthoredCount,
+			AuthoredCount:   stat.AuthoredCount,
+			CoAuthoredCount: stat.CoAuthoredCount,
+		}
+	}
+
 	for i, stat := range prStats {
 		response.PRStats[i] = PRStatusStatResponse{
 			Status: stat.Status,
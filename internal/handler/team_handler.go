@@ -48,3 +48,86 @@ func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(domainTeamToHTTP(team))
 }
+
+func (h *Handler) SetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var req TeamSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	team, err := h.teamService.UpdateSettings(r.Context(), req.TeamName, req.MinReviewers, req.MaxReviewers)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(TeamSettingsResponse{
+		Team: domainTeamToHTTP(team),
+	})
+}
+
+func (h *Handler) SetMandatoryReviewers(w http.ResponseWriter, r *http.Request) {
+	var req SetMandatoryReviewersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	team, err := h.teamService.SetMandatoryReviewers(r.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SetMandatoryReviewersResponse{
+		Team: domainTeamToHTTP(team),
+	})
+}
+
+func (h *Handler) SetMergePolicy(w http.ResponseWriter, r *http.Request) {
+	var req SetMergePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	team, err := h.teamService.SetMergePolicy(r.Context(), req.TeamName, domain.MergePolicy{
+		RequiredApprovals:       req.RequiredApprovals,
+		BlockOnChangesRequested: req.BlockOnChangesRequested,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SetMergePolicyResponse{
+		Team: domainTeamToHTTP(team),
+	})
+}
+
+func (h *Handler) SetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	var req SetFallbackTeamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	team, err := h.teamService.SetFallbackTeams(r.Context(), req.TeamName, req.FallbackTeams)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SetFallbackTeamsResponse{
+		Team: domainTeamToHTTP(team),
+	})
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+func (h *Handler) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req UnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	unavailability, err := h.unavailabilityService.Create(r.Context(), req.UserID, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(UnavailabilityItemResponse{
+		Unavailability: domainUnavailabilityToHTTP(unavailability),
+	})
+}
+
+func (h *Handler) GetUnavailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		h.handleError(w, &domain.DomainError{
+			Code:    "BAD_REQUEST",
+			Message: "user_id parameter is required",
+		})
+		return
+	}
+
+	unavailabilities, err := h.unavailabilityService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(GetUnavailabilityResponse{
+		UserID:         userID,
+		Unavailability: domainUnavailabilitiesToHTTP(unavailabilities),
+	})
+}
+
+func (h *Handler) UpdateUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req UpdateUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	unavailability, err := h.unavailabilityService.Update(r.Context(), req.ID, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(UnavailabilityItemResponse{
+		Unavailability: domainUnavailabilityToHTTP(unavailability),
+	})
+}
+
+func (h *Handler) DeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req DeleteUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := h.unavailabilityService.Delete(r.Context(), req.ID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(DeleteUnavailabilityResponse{
+		ID: req.ID,
+	})
+}
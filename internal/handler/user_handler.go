@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
 )
@@ -14,7 +15,7 @@ func (h *Handler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userService.SetIsActive(r.Context(), req.UserID, req.IsActive)
+	user, replacements, err := h.userService.SetIsActive(r.Context(), req.UserID, req.IsActive, req.ReassignOpenReviews)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -23,6 +24,65 @@ func (h *Handler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(SetIsActiveResponse{
+		User:          domainUserToHTTP(user),
+		ReassignedPRs: domainReplacementsToHTTP(replacements),
+	})
+}
+
+func (h *Handler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
+	var req BulkDeactivateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	result, err := h.userService.BulkDeactivate(r.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domainBulkDeactivationToHTTP(result))
+}
+
+func (h *Handler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req SetMaxOpenReviewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	user, err := h.userService.SetMaxOpenReviews(r.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SetMaxOpenReviewsResponse{
+		User: domainUserToHTTP(user),
+	})
+}
+
+func (h *Handler) SetSelectionWeight(w http.ResponseWriter, r *http.Request) {
+	var req SetSelectionWeightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	user, err := h.userService.SetSelectionWeight(r.Context(), req.UserID, req.SelectionWeight)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SetSelectionWeightResponse{
 		User: domainUserToHTTP(user),
 	})
 }
@@ -37,7 +97,29 @@ func (h *Handler) GetReviewPRs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	prs, err := h.userService.GetReviewPRs(r.Context(), userID)
+	awaitingReview := false
+	if value := r.URL.Query().Get("awaiting_review"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			h.handleError(w, domain.NewBadRequestError("awaiting_review must be a boolean"))
+			return
+		}
+		awaitingReview = parsed
+	}
+
+	var prs []*domain.PullRequestShort
+	var err error
+	if awaitingReview {
+		prs, err = h.userService.GetAwaitingReviewPRs(r.Context(), userID)
+	} else {
+		prs, err = h.userService.GetReviewPRs(r.Context(), userID)
+	}
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	load, err := h.userService.GetReviewLoad(r.Context(), userID)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -46,7 +128,29 @@ func (h *Handler) GetReviewPRs(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(GetReviewPRsResponse{
-		UserID:       userID,
-		PullRequests: domainPRShortsToHTTP(prs),
+		UserID:         userID,
+		PullRequests:   domainPRShortsToHTTP(prs),
+		OpenReviews:    load.OpenReviews,
+		MaxOpenReviews: load.MaxOpenReviews,
+	})
+}
+
+func (h *Handler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	var req SetSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	user, err := h.userService.SetSkills(r.Context(), req.UserID, req.Skills)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(SetSkillsResponse{
+		User: domainUserToHTTP(user),
 	})
 }
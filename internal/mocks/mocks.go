@@ -25,6 +25,47 @@ func (m *MockTeamRepository) GetByName(ctx context.Context, name string) (*domai
 	return args.Get(0).(*domain.Team), args.Error(1)
 }
 
+func (m *MockTeamRepository) UpdateSettings(ctx context.Context, teamID int, minReviewers, maxReviewers int) error {
+	args := m.Called(ctx, teamID, minReviewers, maxReviewers)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetFallbackTeams(ctx context.Context, teamID int) ([]*domain.Team, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetMandatoryReviewers(ctx context.Context, teamID int) ([]string, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTeamRepository) SetMandatoryReviewers(ctx context.Context, teamID int, userIDs []string) error {
+	args := m.Called(ctx, teamID, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetMergePolicy(ctx context.Context, teamID int) (domain.MergePolicy, error) {
+	args := m.Called(ctx, teamID)
+	return args.Get(0).(domain.MergePolicy), args.Error(1)
+}
+
+func (m *MockTeamRepository) SetMergePolicy(ctx context.Context, teamID int, policy domain.MergePolicy) error {
+	args := m.Called(ctx, teamID, policy)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) SetFallbackTeams(ctx context.Context, teamID int, fallbackTeamIDs []int) error {
+	args := m.Called(ctx, teamID, fallbackTeamIDs)
+	return args.Error(0)
+}
+
 type MockUserRepository struct {
 	mock.Mock
 }
@@ -73,6 +114,37 @@ func (m *MockUserRepository) SetIsActive(ctx context.Context, userID string, isA
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	args := m.Called(ctx, userID, maxOpenReviews)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetSelectionWeight(ctx context.Context, userID string, weight float64) error {
+	args := m.Called(ctx, userID, weight)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetSkills(ctx context.Context, userID string, skills []string) error {
+	args := m.Called(ctx, userID, skills)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetSkills(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) GetSkillsByTeamID(ctx context.Context, teamID int) (map[string][]string, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
 type MockPullRequestRepository struct {
 	mock.Mock
 }
@@ -90,6 +162,14 @@ func (m *MockPullRequestRepository) GetByID(ctx context.Context, id string) (*do
 	return args.Get(0).(*domain.PullRequest), args.Error(1)
 }
 
+func (m *MockPullRequestRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.PullRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PullRequest), args.Error(1)
+}
+
 func (m *MockPullRequestRepository) UpdateStatus(ctx context.Context, id string, status domain.Status, mergedAt *time.Time) error {
 	args := m.Called(ctx, id, status, mergedAt)
 	return args.Error(0)
@@ -125,3 +205,148 @@ func (m *MockPullRequestRepository) ReplaceReviewer(ctx context.Context, prID st
 	args := m.Called(ctx, prID, oldReviewerID, newReviewerID)
 	return args.Error(0)
 }
+
+func (m *MockPullRequestRepository) GetOpenReviewCountsByTeamID(ctx context.Context, teamID int) (map[string]int, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetRecentReviewCounts(ctx context.Context, authorID string, lastPRs int) (map[string]int, error) {
+	args := m.Called(ctx, authorID, lastPRs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) CreateAssignment(ctx context.Context, assignment *domain.Assignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) GetAwaitingReviewPRs(ctx context.Context, reviewerID string) ([]*domain.PullRequestShort, error) {
+	args := m.Called(ctx, reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequestShort), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) CreateReview(ctx context.Context, review *domain.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) List(ctx context.Context, filter domain.PullRequestFilter) ([]*domain.PullRequest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) CreateMergeOverride(ctx context.Context, override *domain.MergeOverride) error {
+	args := m.Called(ctx, override)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) GetAssignments(ctx context.Context, prID string) ([]*domain.Assignment, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Assignment), args.Error(1)
+}
+
+type MockRotationRepository struct {
+	mock.Mock
+	// Cursor - курсор, сохраненный последним вызовом AdvanceCursor
+	Cursor string
+}
+
+// AdvanceCursor передает в next курсор, заданный через Return, и запоминает новый курсор в Cursor
+func (m *MockRotationRepository) AdvanceCursor(ctx context.Context, teamID int, next func(cursorID string) (string, error)) error {
+	args := m.Called(ctx, teamID)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	newCursor, err := next(args.String(0))
+	if err != nil {
+		return err
+	}
+	m.Cursor = newCursor
+	return nil
+}
+
+func (m *MockRotationRepository) GetCursor(ctx context.Context, teamID int) (string, error) {
+	args := m.Called(ctx, teamID)
+	return args.String(0), args.Error(1)
+}
+
+type MockUnavailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUnavailabilityRepository) Create(ctx context.Context, unavailability *domain.Unavailability) error {
+	args := m.Called(ctx, unavailability)
+	return args.Error(0)
+}
+
+func (m *MockUnavailabilityRepository) GetByID(ctx context.Context, id int) (*domain.Unavailability, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Unavailability), args.Error(1)
+}
+
+func (m *MockUnavailabilityRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Unavailability, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Unavailability), args.Error(1)
+}
+
+func (m *MockUnavailabilityRepository) Update(ctx context.Context, unavailability *domain.Unavailability) error {
+	args := m.Called(ctx, unavailability)
+	return args.Error(0)
+}
+
+func (m *MockUnavailabilityRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUnavailabilityRepository) GetStartedPending(ctx context.Context) ([]*domain.Unavailability, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Unavailability), args.Error(1)
+}
+
+func (m *MockUnavailabilityRepository) MarkReviewsReassigned(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockCodeOwnersRepository struct {
+	mock.Mock
+}
+
+func (m *MockCodeOwnersRepository) Upsert(ctx context.Context, codeOwners *domain.CodeOwners) error {
+	args := m.Called(ctx, codeOwners)
+	return args.Error(0)
+}
+
+func (m *MockCodeOwnersRepository) GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CodeOwners), args.Error(1)
+}
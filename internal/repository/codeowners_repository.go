@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+type CodeOwnersRepository interface {
+	// Upsert сохраняет файл CODEOWNERS репозитория, заменяя ранее загруженный
+	Upsert(ctx context.Context, codeOwners *domain.CodeOwners) error
+	GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error)
+}
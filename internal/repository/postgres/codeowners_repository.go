@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+type codeOwnersRepository struct {
+	executor DBExecutor
+}
+
+func NewCodeOwnersRepository(db *sql.DB) *codeOwnersRepository {
+	return &codeOwnersRepository{executor: db}
+}
+
+func NewCodeOwnersRepositoryWithTx(tx *sql.Tx) *codeOwnersRepository {
+	return &codeOwnersRepository{executor: tx}
+}
+
+func (r *codeOwnersRepository) Upsert(ctx context.Context, codeOwners *domain.CodeOwners) error {
+	query := `
+		INSERT INTO repository_codeowners (repository, content, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (repository) DO UPDATE SET content = EXCLUDED.content, updated_at = EXCLUDED.created_at
+		RETURNING created_at, updated_at
+	`
+
+	var updatedAt sql.NullTime
+	err := r.executor.QueryRowContext(
+		ctx,
+		query,
+		codeOwners.Repository,
+		codeOwners.Content,
+		time.Now(),
+	).Scan(&codeOwners.CreatedAt, &updatedAt)
+	if err != nil {
+		return err
+	}
+
+	codeOwners.UpdatedAt = nil
+	if updatedAt.Valid {
+		codeOwners.UpdatedAt = &updatedAt.Time
+	}
+
+	return nil
+}
+
+func (r *codeOwnersRepository) GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	query := `
+		SELECT repository, content, created_at, updated_at
+		FROM repository_codeowners
+		WHERE repository = $1
+	`
+
+	codeOwners := &domain.CodeOwners{}
+	var updatedAt sql.NullTime
+	err := r.executor.QueryRowContext(ctx, query, repository).Scan(
+		&codeOwners.Repository,
+		&codeOwners.Content,
+		&codeOwners.CreatedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("codeowners not found")
+		}
+		return nil, err
+	}
+
+	if updatedAt.Valid {
+		codeOwners.UpdatedAt = &updatedAt.Time
+	}
+
+	return codeOwners, nil
+}
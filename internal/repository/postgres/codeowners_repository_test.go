@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCodeOwnersRepo создает мок БД и репозиторий для файлов CODEOWNERS
+func setupCodeOwnersRepo(t *testing.T) (*codeOwnersRepository, sqlmock.Sqlmock) {
+	db, mock := setupMockDB(t)
+	return NewCodeOwnersRepository(db), mock
+}
+
+// TestCodeOwnersRepository_Upsert - тест для метода Upsert()
+// Повторная загрузка файла для репозитория заменяет содержимое через ON CONFLICT
+func TestCodeOwnersRepository_Upsert(t *testing.T) {
+	t.Run("успешное сохранение нового файла", func(t *testing.T) {
+		repo, mock := setupCodeOwnersRepo(t)
+
+		createdAt := time.Now()
+		mock.ExpectQuery("INSERT INTO repository_codeowners").
+			WithArgs("avito/pr-reviewer", "* @u1", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, nil))
+
+		codeOwners := &domain.CodeOwners{Repository: "avito/pr-reviewer", Content: "* @u1"}
+		err := repo.Upsert(context.Background(), codeOwners)
+
+		require.NoError(t, err)
+		assert.Equal(t, createdAt, codeOwners.CreatedAt)
+		assert.Nil(t, codeOwners.UpdatedAt)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("замена ранее загруженного файла", func(t *testing.T) {
+		repo, mock := setupCodeOwnersRepo(t)
+
+		createdAt := time.Now().Add(-time.Hour)
+		updatedAt := time.Now()
+		mock.ExpectQuery("ON CONFLICT \\(repository\\) DO UPDATE").
+			WithArgs("avito/pr-reviewer", "* @u2", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, updatedAt))
+
+		codeOwners := &domain.CodeOwners{Repository: "avito/pr-reviewer", Content: "* @u2"}
+		err := repo.Upsert(context.Background(), codeOwners)
+
+		require.NoError(t, err)
+		require.NotNil(t, codeOwners.UpdatedAt)
+		assert.Equal(t, updatedAt, *codeOwners.UpdatedAt)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestCodeOwnersRepository_GetByRepository - тест для метода GetByRepository()
+func TestCodeOwnersRepository_GetByRepository(t *testing.T) {
+	t.Run("успешное получение файла", func(t *testing.T) {
+		repo, mock := setupCodeOwnersRepo(t)
+
+		createdAt := time.Now()
+		mock.ExpectQuery("SELECT repository, content, created_at, updated_at").
+			WithArgs("avito/pr-reviewer").
+			WillReturnRows(sqlmock.NewRows([]string{"repository", "content", "created_at", "updated_at"}).
+				AddRow("avito/pr-reviewer", "* @u1", createdAt, nil))
+
+		codeOwners, err := repo.GetByRepository(context.Background(), "avito/pr-reviewer")
+
+		require.NoError(t, err)
+		assert.Equal(t, "avito/pr-reviewer", codeOwners.Repository)
+		assert.Equal(t, "* @u1", codeOwners.Content)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: файл не загружен", func(t *testing.T) {
+		repo, mock := setupCodeOwnersRepo(t)
+
+		mock.ExpectQuery("SELECT repository, content").
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		codeOwners, err := repo.GetByRepository(context.Background(), "unknown")
+
+		require.Error(t, err)
+		assert.Nil(t, codeOwners)
+		assert.Equal(t, "codeowners not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -20,6 +21,16 @@ func NewPullRequestRepository(db *sql.DB) *pullRequestRepository {
 	return &pullRequestRepository{executor: db}
 }
 
+func NewPullRequestRepositoryWithTx(tx *sql.Tx) *pullRequestRepository {
+	return &pullRequestRepository{executor: tx}
+}
+
+// insertReviewerQuery назначает ревьювера на PR и запоминает команду, из которой он был выбран
+const insertReviewerQuery = `
+	INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, created_at, source_team_id)
+	VALUES ($1, $2, $3, (SELECT team_id FROM users WHERE id = $2))
+`
+
 func prStringIDToInt(stringID string) (int, error) {
 	idStr := strings.TrimPrefix(stringID, "pr-")
 	return strconv.Atoi(idStr)
@@ -83,7 +94,7 @@ func (r *pullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 
 		_, err = r.executor.ExecContext(
 			ctx,
-			"INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, created_at) VALUES ($1, $2, $3)",
+			insertReviewerQuery,
 			prDBID,
 			reviewerDBID,
 			now,
@@ -93,6 +104,35 @@ func (r *pullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 		}
 	}
 
+	for _, tag := range pr.Tags {
+		_, err = r.executor.ExecContext(
+			ctx,
+			"INSERT INTO pull_request_tags (pull_request_id, tag) VALUES ($1, $2)",
+			prDBID,
+			tag,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, coAuthorID := range pr.CoAuthorIDs {
+		coAuthorDBID, err := stringIDToInt(coAuthorID)
+		if err != nil {
+			return errors.New("invalid co-author ID")
+		}
+
+		_, err = r.executor.ExecContext(
+			ctx,
+			"INSERT INTO pull_request_co_authors (pull_request_id, user_id) VALUES ($1, $2)",
+			prDBID,
+			coAuthorDBID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -103,7 +143,18 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, id string) (*domain
 	}
 
 	query := `
-		SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.updated_at
+		SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at,
+			COALESCE((SELECT string_agg(prt.tag, ',' ORDER BY prt.tag) FROM pull_request_tags prt WHERE prt.pull_request_id = pr.id), ''),
+			COALESCE((SELECT string_agg(pca.user_id::text, ',' ORDER BY pca.user_id) FROM pull_request_co_authors pca WHERE pca.pull_request_id = pr.id), ''),
+			COALESCE((
+				SELECT string_agg(latest.reviewer_id || ':' || latest.state, ',' ORDER BY latest.reviewer_id)
+				FROM (
+					SELECT DISTINCT ON (prv.reviewer_id) prv.reviewer_id, prv.state
+					FROM pull_request_reviews prv
+					WHERE prv.pull_request_id = pr.id
+					ORDER BY prv.reviewer_id, prv.id DESC
+				) latest
+			), '')
 		FROM pull_requests pr
 		JOIN users u ON pr.author_id = u.id
 		JOIN statuses s ON pr.status_id = s.id
@@ -113,15 +164,22 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, id string) (*domain
 	pr := &domain.PullRequest{}
 	var statusName string
 	var createdAt time.Time
-	var updatedAt sql.NullTime
+	var mergedAt, closedAt sql.NullTime
 	var authorDBID int
+	var tags string
+	var coAuthors string
+	var reviewStates string
 	err = r.executor.QueryRowContext(ctx, query, prDBID).Scan(
 		&prDBID,
 		&pr.Title,
 		&authorDBID,
 		&statusName,
 		&createdAt,
-		&updatedAt,
+		&mergedAt,
+		&closedAt,
+		&tags,
+		&coAuthors,
+		&reviewStates,
 	)
 
 	if err != nil {
@@ -135,21 +193,286 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, id string) (*domain
 	pr.AuthorID = intToStringID(authorDBID)
 	pr.Status = domain.Status(statusName)
 	pr.CreatedAt = createdAt
+	pr.Tags = []string{}
+	if tags != "" {
+		pr.Tags = strings.Split(tags, ",")
+	}
+	pr.CoAuthorIDs, err = parseCoAuthorIDs(coAuthors)
+	if err != nil {
+		return nil, err
+	}
 
-	reviewers, err := r.GetReviewersByPRID(ctx, id)
+	reviewers, reviewerTeams, err := r.getReviewersWithTeams(ctx, prDBID)
 	if err != nil {
 		return nil, err
 	}
 	pr.AssignedReviewers = reviewers
+	pr.ReviewerTeams = reviewerTeams
+
+	pr.ReviewStates, err = parseReviewStates(reviewStates, reviewers)
+	if err != nil {
+		return nil, err
+	}
 
-	if pr.Status == domain.StatusMerged && updatedAt.Valid {
-		pr.MergedAt = &updatedAt.Time
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+	if closedAt.Valid {
+		pr.ClosedAt = &closedAt.Time
 	}
 
 	return pr, nil
 }
 
-func (r *pullRequestRepository) UpdateStatus(ctx context.Context, id string, status domain.Status, mergedAt *time.Time) error {
+// GetByIDForUpdate блокирует строку PR до конца транзакции и возвращает PR.
+// Проверки, сделанные по возвращенному PR, остаются верными до фиксации транзакции.
+func (r *pullRequestRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.PullRequest, error) {
+	prDBID, err := prStringIDToInt(id)
+	if err != nil {
+		return nil, errors.New("invalid pull request ID")
+	}
+
+	err = r.executor.QueryRowContext(ctx, "SELECT id FROM pull_requests WHERE id = $1 FOR UPDATE", prDBID).Scan(&prDBID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("pull request not found")
+		}
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// listTimestampLayout - формат значения TIMESTAMP без часового пояса, с которым сравниваются курсор и границы периода списка
+const listTimestampLayout = "2006-01-02 15:04:05.999999"
+
+// storedTimestamp переводит момент времени в локальный пояс приложения, в котором Create записывает pr.created_at,
+// и форматирует его как TIMESTAMP без часового пояса
+func storedTimestamp(t time.Time) string {
+	return t.In(time.Local).Format(listTimestampLayout)
+}
+
+// List возвращает PR, удовлетворяющие filter, упорядоченные по времени создания и ID, с теми же полями, что и GetByID.
+// Страница начинается после filter.After (keyset-пагинация). Ревьюверы возвращаются в порядке назначения.
+func (r *pullRequestRepository) List(ctx context.Context, filter domain.PullRequestFilter) ([]*domain.PullRequest, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		addCondition("s.name = $%d", string(filter.Status))
+	}
+	if filter.AuthorID != "" {
+		authorDBID, err := stringIDToInt(filter.AuthorID)
+		if err != nil {
+			return nil, errors.New("invalid author ID")
+		}
+		addCondition("pr.author_id = $%d", authorDBID)
+	}
+	if filter.ReviewerID != "" {
+		reviewerDBID, err := stringIDToInt(filter.ReviewerID)
+		if err != nil {
+			return nil, errors.New("invalid reviewer ID")
+		}
+		addCondition("EXISTS (SELECT 1 FROM pull_request_reviewers f WHERE f.pull_request_id = pr.id AND f.reviewer_id = $%d)", reviewerDBID)
+	}
+	if filter.TeamName != "" {
+		addCondition("u.team_id = (SELECT id FROM teams WHERE name = $%d)", filter.TeamName)
+	}
+	// Границы периода приходят с произвольным смещением, а pr.created_at хранится без пояса,
+	// поэтому они переводятся в пояс хранения, а не отбрасывают смещение при передаче в запрос
+	if filter.CreatedFrom != nil {
+		addCondition("pr.created_at >= $%d::timestamp", storedTimestamp(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		addCondition("pr.created_at < $%d::timestamp", storedTimestamp(*filter.CreatedTo))
+	}
+
+	order, comparison := "DESC", "<"
+	if filter.Ascending {
+		order, comparison = "ASC", ">"
+	}
+	if filter.After != nil {
+		afterDBID, err := prStringIDToInt(filter.After.PullRequestID)
+		if err != nil {
+			return nil, errors.New("invalid pull request ID")
+		}
+		// pr.created_at хранится без часового пояса, поэтому курсор передается как дата и время без пояса
+		args = append(args, filter.After.CreatedAt.Format(listTimestampLayout), afterDBID)
+		conditions = append(conditions, fmt.Sprintf("(pr.created_at, pr.id) %s ($%d::timestamp, $%d)", comparison, len(args)-1, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT pr.id, pr.title, pr.author_id, s.name, pr.created_at, pr.merged_at, pr.closed_at,
+			COALESCE((SELECT string_agg(prt.tag, ',' ORDER BY prt.tag) FROM pull_request_tags prt WHERE prt.pull_request_id = pr.id), ''),
+			COALESCE((SELECT string_agg(pca.user_id::text, ',' ORDER BY pca.user_id) FROM pull_request_co_authors pca WHERE pca.pull_request_id = pr.id), ''),
+			COALESCE((
+				SELECT json_agg(json_build_object('id', prr.reviewer_id, 'team', COALESCE(t.name, '')) ORDER BY prr.created_at)
+				FROM pull_request_reviewers prr
+				LEFT JOIN teams t ON prr.source_team_id = t.id
+				WHERE prr.pull_request_id = pr.id
+			), '[]'),
+			COALESCE((
+				SELECT string_agg(latest.reviewer_id || ':' || latest.state, ',' ORDER BY latest.reviewer_id)
+				FROM (
+					SELECT DISTINCT ON (prv.reviewer_id) prv.reviewer_id, prv.state
+					FROM pull_request_reviews prv
+					WHERE prv.pull_request_id = pr.id
+					ORDER BY prv.reviewer_id, prv.id DESC
+				) latest
+			), '')
+		FROM pull_requests pr
+		JOIN users u ON pr.author_id = u.id
+		JOIN statuses s ON pr.status_id = s.id
+		%s
+		ORDER BY pr.created_at %s, pr.id %s
+		LIMIT $%d
+	`, where, order, order, len(args))
+
+	rows, err := r.executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := []*domain.PullRequest{}
+	for rows.Next() {
+		pr := &domain.PullRequest{}
+		var prDBID, authorDBID int
+		var statusName, tags, coAuthors, reviewStates string
+		var reviewers []byte
+		var mergedAt, closedAt sql.NullTime
+		err := rows.Scan(
+			&prDBID,
+			&pr.Title,
+			&authorDBID,
+			&statusName,
+			&pr.CreatedAt,
+			&mergedAt,
+			&closedAt,
+			&tags,
+			&coAuthors,
+			&reviewers,
+			&reviewStates,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pr.ID = prIntToStringID(prDBID)
+		pr.AuthorID = intToStringID(authorDBID)
+		pr.Status = domain.Status(statusName)
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+		if closedAt.Valid {
+			pr.ClosedAt = &closedAt.Time
+		}
+		pr.Tags = []string{}
+		if tags != "" {
+			pr.Tags = strings.Split(tags, ",")
+		}
+		pr.CoAuthorIDs, err = parseCoAuthorIDs(coAuthors)
+		if err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers, pr.ReviewerTeams, err = parseReviewersWithTeams(reviewers)
+		if err != nil {
+			return nil, err
+		}
+		pr.ReviewStates, err = parseReviewStates(reviewStates, pr.AssignedReviewers)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// parseCoAuthorIDs разбирает ID соавторов в формате "id,..."
+func parseCoAuthorIDs(value string) ([]string, error) {
+	coAuthorIDs := []string{}
+	if value == "" {
+		return coAuthorIDs, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		coAuthorDBID, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		coAuthorIDs = append(coAuthorIDs, intToStringID(coAuthorDBID))
+	}
+	return coAuthorIDs, nil
+}
+
+// parseReviewersWithTeams разбирает ревьюверов в формате JSON [{"id": 2, "team": "backend"}, ...]
+// и возвращает их ID и команды, из которых они были выбраны (как getReviewersWithTeams)
+func parseReviewersWithTeams(data []byte) ([]string, map[string]string, error) {
+	var items []struct {
+		ID   int    `json:"id"`
+		Team string `json:"team"`
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, nil, err
+	}
+
+	reviewers := make([]string, 0, len(items))
+	reviewerTeams := make(map[string]string)
+	for _, item := range items {
+		reviewerID := intToStringID(item.ID)
+		reviewers = append(reviewers, reviewerID)
+		if item.Team != "" {
+			reviewerTeams[reviewerID] = item.Team
+		}
+	}
+	return reviewers, reviewerTeams, nil
+}
+
+// parseReviewStates разбирает последние решения ревьюверов в формате "id:state,..."
+// и оставляет только решения ревьюверов, назначенных на PR сейчас
+func parseReviewStates(value string, reviewers []string) (map[string]domain.ReviewState, error) {
+	states := make(map[string]domain.ReviewState)
+	if value == "" {
+		return states, nil
+	}
+
+	assigned := make(map[string]bool, len(reviewers))
+	for _, reviewerID := range reviewers {
+		assigned[reviewerID] = true
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		reviewerDBIDStr, state, found := strings.Cut(entry, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid review state %q", entry)
+		}
+		reviewerDBID, err := strconv.Atoi(reviewerDBIDStr)
+		if err != nil {
+			return nil, err
+		}
+		reviewerID := intToStringID(reviewerDBID)
+		if assigned[reviewerID] {
+			states[reviewerID] = domain.ReviewState(state)
+		}
+	}
+
+	return states, nil
+}
+
+// UpdateStatus переводит PR в статус status в момент changedAt (nil - текущее время).
+// Для MERGED и CLOSED момент перехода сохраняется в merged_at и closed_at; при повторном открытии closed_at сбрасывается.
+func (r *pullRequestRepository) UpdateStatus(ctx context.Context, id string, status domain.Status, changedAt *time.Time) error {
 	prDBID, err := prStringIDToInt(id)
 	if err != nil {
 		return errors.New("invalid pull request ID")
@@ -163,18 +486,26 @@ func (r *pullRequestRepository) UpdateStatus(ctx context.Context, id string, sta
 
 	query := `
 		UPDATE pull_requests
-		SET status_id = $2, updated_at = $3
+		SET status_id = $2, updated_at = $3, merged_at = $4, closed_at = $5
 		WHERE id = $1
 		RETURNING id
 	`
 
 	updateTime := time.Now()
-	if mergedAt != nil {
-		updateTime = *mergedAt
+	if changedAt != nil {
+		updateTime = *changedAt
+	}
+
+	var mergedAt, closedAt sql.NullTime
+	switch status {
+	case domain.StatusMerged:
+		mergedAt = sql.NullTime{Time: updateTime, Valid: true}
+	case domain.StatusClosed:
+		closedAt = sql.NullTime{Time: updateTime, Valid: true}
 	}
 
 	var prID int
-	err = r.executor.QueryRowContext(ctx, query, prDBID, statusID, updateTime).Scan(&prID)
+	err = r.executor.QueryRowContext(ctx, query, prDBID, statusID, updateTime, mergedAt, closedAt).Scan(&prID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return errors.New("pull request not found")
@@ -198,7 +529,7 @@ func (r *pullRequestRepository) AddReviewer(ctx context.Context, prID string, re
 
 	_, err = r.executor.ExecContext(
 		ctx,
-		"INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, created_at) VALUES ($1, $2, $3)",
+		insertReviewerQuery,
 		prDBID,
 		reviewerDBID,
 		time.Now(),
@@ -276,6 +607,40 @@ func (r *pullRequestRepository) GetReviewersByPRID(ctx context.Context, prID str
 	return reviewers, rows.Err()
 }
 
+// getReviewersWithTeams возвращает ревьюверов PR и команды, из которых они были выбраны (ID ревьювера -> имя команды)
+func (r *pullRequestRepository) getReviewersWithTeams(ctx context.Context, prDBID int) ([]string, map[string]string, error) {
+	query := `
+		SELECT prr.reviewer_id, COALESCE(t.name, '')
+		FROM pull_request_reviewers prr
+		LEFT JOIN teams t ON prr.source_team_id = t.id
+		WHERE prr.pull_request_id = $1
+		ORDER BY prr.created_at
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query, prDBID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var reviewers []string
+	reviewerTeams := make(map[string]string)
+	for rows.Next() {
+		var reviewerDBID int
+		var teamName string
+		if err := rows.Scan(&reviewerDBID, &teamName); err != nil {
+			return nil, nil, err
+		}
+		reviewerID := intToStringID(reviewerDBID)
+		reviewers = append(reviewers, reviewerID)
+		if teamName != "" {
+			reviewerTeams[reviewerID] = teamName
+		}
+	}
+
+	return reviewers, reviewerTeams, rows.Err()
+}
+
 func (r *pullRequestRepository) GetPRsByReviewerID(ctx context.Context, reviewerID string) ([]*domain.PullRequestShort, error) {
 	reviewerDBID, err := stringIDToInt(reviewerID)
 	if err != nil {
@@ -322,6 +687,107 @@ func (r *pullRequestRepository) GetPRsByReviewerID(ctx context.Context, reviewer
 	return prs, rows.Err()
 }
 
+// GetAwaitingReviewPRs возвращает OPEN PR, где reviewerID назначен и еще не отправил решение
+// APPROVED или CHANGES_REQUESTED (COMMENTED решением не считается)
+func (r *pullRequestRepository) GetAwaitingReviewPRs(ctx context.Context, reviewerID string) ([]*domain.PullRequestShort, error) {
+	reviewerDBID, err := stringIDToInt(reviewerID)
+	if err != nil {
+		return nil, errors.New("invalid reviewer ID")
+	}
+
+	query := `
+		SELECT pr.id, pr.title, u.id, s.name
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON prr.pull_request_id = pr.id
+		JOIN users u ON pr.author_id = u.id
+		JOIN statuses s ON pr.status_id = s.id
+		WHERE prr.reviewer_id = $1
+			AND s.name = $2
+			AND NOT EXISTS (
+				SELECT 1 FROM pull_request_reviews prv
+				WHERE prv.pull_request_id = pr.id
+					AND prv.reviewer_id = prr.reviewer_id
+					AND prv.state IN ($3, $4)
+			)
+		ORDER BY pr.created_at DESC
+	`
+
+	rows, err := r.executor.QueryContext(
+		ctx,
+		query,
+		reviewerDBID,
+		string(domain.StatusOpen),
+		string(domain.ReviewApproved),
+		string(domain.ReviewChangesRequested),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := []*domain.PullRequestShort{}
+	for rows.Next() {
+		pr := &domain.PullRequestShort{}
+		var statusName string
+		var prDBID, authorDBID int
+		err := rows.Scan(&prDBID, &pr.Title, &authorDBID, &statusName)
+		if err != nil {
+			return nil, err
+		}
+		pr.ID = prIntToStringID(prDBID)
+		pr.AuthorID = intToStringID(authorDBID)
+		pr.Status = domain.Status(statusName)
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// CreateReview сохраняет решение ревьювера и заполняет его ID и время создания
+func (r *pullRequestRepository) CreateReview(ctx context.Context, review *domain.Review) error {
+	prDBID, err := prStringIDToInt(review.PullRequestID)
+	if err != nil {
+		return errors.New("invalid pull request ID")
+	}
+
+	reviewerDBID, err := stringIDToInt(review.ReviewerID)
+	if err != nil {
+		return errors.New("invalid reviewer ID")
+	}
+
+	return r.executor.QueryRowContext(
+		ctx,
+		`INSERT INTO pull_request_reviews (pull_request_id, reviewer_id, state, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		prDBID,
+		reviewerDBID,
+		string(review.State),
+		time.Now(),
+	).Scan(&review.ID, &review.CreatedAt)
+}
+
+// CreateMergeOverride записывает в журнал merge PR в обход политики команды
+func (r *pullRequestRepository) CreateMergeOverride(ctx context.Context, override *domain.MergeOverride) error {
+	prDBID, err := prStringIDToInt(override.PullRequestID)
+	if err != nil {
+		return errors.New("invalid pull request ID")
+	}
+
+	return r.executor.QueryRowContext(
+		ctx,
+		`INSERT INTO pull_request_merge_overrides (pull_request_id, reason, violation, approvals, required_approvals, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		prDBID,
+		override.Reason,
+		override.Violation,
+		override.Approvals,
+		override.RequiredApprovals,
+		time.Now(),
+	).Scan(&override.ID, &override.CreatedAt)
+}
+
 func (r *pullRequestRepository) ReplaceReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) error {
 	prDBID, err := prStringIDToInt(prID)
 	if err != nil {
@@ -374,7 +840,7 @@ func (r *pullRequestRepository) ReplaceReviewer(ctx context.Context, prID string
 		// Если нового ревьювера нет, делаем UPDATE
 		result, err := r.executor.ExecContext(
 		ctx,
-		"UPDATE pull_request_reviewers SET reviewer_id = $1 WHERE pull_request_id = $2 AND reviewer_id = $3",
+		"UPDATE pull_request_reviewers SET reviewer_id = $1, source_team_id = (SELECT team_id FROM users WHERE id = $1) WHERE pull_request_id = $2 AND reviewer_id = $3",
 		newReviewerDBID,
 		prDBID,
 		oldReviewerDBID,
@@ -395,3 +861,304 @@ func (r *pullRequestRepository) ReplaceReviewer(ctx context.Context, prID string
 
 	return nil
 }
+
+// GetOpenReviewCountsByTeamID возвращает количество OPEN PR на ревью у каждого участника команды
+func (r *pullRequestRepository) GetOpenReviewCountsByTeamID(ctx context.Context, teamID int) (map[string]int, error) {
+	query := `
+		SELECT u.id, COUNT(pr.id)
+		FROM users u
+		LEFT JOIN pull_request_reviewers prr ON prr.reviewer_id = u.id
+		LEFT JOIN pull_requests pr ON pr.id = prr.pull_request_id
+			AND pr.status_id = (SELECT id FROM statuses WHERE name = $2)
+		WHERE u.team_id = $1
+		GROUP BY u.id
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query, teamID, string(domain.StatusOpen))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var userDBID, count int
+		if err := rows.Scan(&userDBID, &count); err != nil {
+			return nil, err
+		}
+		counts[intToStringID(userDBID)] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetRecentReviewCounts возвращает, сколько из последних lastPRs PR автора authorID ревьюил каждый пользователь
+func (r *pullRequestRepository) GetRecentReviewCounts(ctx context.Context, authorID string, lastPRs int) (map[string]int, error) {
+	authorDBID, err := stringIDToInt(authorID)
+	if err != nil {
+		return nil, errors.New("invalid author ID")
+	}
+
+	query := `
+		SELECT prr.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		JOIN (
+			SELECT id FROM pull_requests
+			WHERE author_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		) recent ON recent.id = prr.pull_request_id
+		GROUP BY prr.reviewer_id
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query, authorDBID, lastPRs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reviewerDBID, count int
+		if err := rows.Scan(&reviewerDBID, &count); err != nil {
+			return nil, err
+		}
+		counts[intToStringID(reviewerDBID)] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// CreateAssignment сохраняет запись о выборе ревьюверов на PR
+func (r *pullRequestRepository) CreateAssignment(ctx context.Context, assignment *domain.Assignment) error {
+	prDBID, err := prStringIDToInt(assignment.PullRequestID)
+	if err != nil {
+		return errors.New("invalid pull request ID")
+	}
+
+	var replacedReviewer sql.NullInt64
+	if assignment.ReplacedReviewerID != "" {
+		replacedReviewerDBID, err := stringIDToInt(assignment.ReplacedReviewerID)
+		if err != nil {
+			return errors.New("invalid reviewer ID")
+		}
+		replacedReviewer = sql.NullInt64{Int64: int64(replacedReviewerDBID), Valid: true}
+	}
+
+	previousReviewers, err := marshalStrings(assignment.PreviousReviewers)
+	if err != nil {
+		return err
+	}
+	changedFiles, err := marshalStrings(assignment.ChangedFiles)
+	if err != nil {
+		return err
+	}
+	selectedReviewers, err := marshalStrings(assignment.SelectedReviewers)
+	if err != nil {
+		return err
+	}
+	steps, err := marshalSteps(assignment.Steps)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO pull_request_assignments (
+			pull_request_id, event, seed, replaced_reviewer_id,
+			previous_reviewers, repository, changed_files, selected_reviewers, steps, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+		RETURNING id, created_at
+	`
+
+	return r.executor.QueryRowContext(
+		ctx,
+		query,
+		prDBID,
+		string(assignment.Event),
+		assignment.Seed,
+		replacedReviewer,
+		previousReviewers,
+		assignment.Repository,
+		changedFiles,
+		selectedReviewers,
+		steps,
+		time.Now(),
+	).Scan(&assignment.ID, &assignment.CreatedAt)
+}
+
+// GetAssignments возвращает записи о выборе ревьюверов на PR в порядке создания
+func (r *pullRequestRepository) GetAssignments(ctx context.Context, prID string) ([]*domain.Assignment, error) {
+	prDBID, err := prStringIDToInt(prID)
+	if err != nil {
+		return nil, errors.New("invalid pull request ID")
+	}
+
+	query := `
+		SELECT id, event, seed, replaced_reviewer_id, previous_reviewers,
+			COALESCE(repository, ''), changed_files, selected_reviewers, steps, created_at
+		FROM pull_request_assignments
+		WHERE pull_request_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query, prDBID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make([]*domain.Assignment, 0)
+	for rows.Next() {
+		assignment := &domain.Assignment{PullRequestID: prID}
+		var event string
+		var replacedReviewer sql.NullInt64
+		var previousReviewers, changedFiles, selectedReviewers, steps []byte
+		err := rows.Scan(
+			&assignment.ID,
+			&event,
+			&assignment.Seed,
+			&replacedReviewer,
+			&previousReviewers,
+			&assignment.Repository,
+			&changedFiles,
+			&selectedReviewers,
+			&steps,
+			&assignment.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		assignment.Event = domain.AssignmentEvent(event)
+		if replacedReviewer.Valid {
+			assignment.ReplacedReviewerID = intToStringID(int(replacedReviewer.Int64))
+		}
+		if err := json.Unmarshal(previousReviewers, &assignment.PreviousReviewers); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changedFiles, &assignment.ChangedFiles); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(selectedReviewers, &assignment.SelectedReviewers); err != nil {
+			return nil, err
+		}
+		assignment.Steps, err = unmarshalSteps(steps)
+		if err != nil {
+			return nil, err
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, rows.Err()
+}
+
+// marshalStrings кодирует список строк в JSON-массив; nil кодируется как пустой массив
+func marshalStrings(values []string) ([]byte, error) {
+	if values == nil {
+		values = []string{}
+	}
+	return json.Marshal(values)
+}
+
+// selectionStepJSON - шаг трассировки выбора ревьюверов в колонке steps
+type selectionStepJSON struct {
+	Source     string                  `json:"source"`
+	TeamName   string                  `json:"team_name"`
+	Strategy   string                  `json:"strategy"`
+	Candidates []string                `json:"candidates"`
+	Excluded   []excludedCandidateJSON `json:"excluded"`
+	Selected   []string                `json:"selected"`
+	Inputs     *selectionInputsJSON    `json:"inputs,omitempty"`
+}
+
+// selectionInputsJSON - входные данные стратегии на шаге выбора
+type selectionInputsJSON struct {
+	Pool          []string           `json:"pool"`
+	MaxReviewers  int                `json:"max_reviewers"`
+	Weights       map[string]float64 `json:"weights"`
+	Preferred     []string           `json:"preferred,omitempty"`
+	CursorID      string             `json:"cursor_id,omitempty"`
+	Loads         map[string]int     `json:"loads,omitempty"`
+	RecentReviews map[string]int     `json:"recent_reviews,omitempty"`
+}
+
+type excludedCandidateJSON struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// marshalSteps кодирует трассировку выбора в JSON
+func marshalSteps(steps []*domain.SelectionStep) ([]byte, error) {
+	records := make([]selectionStepJSON, 0, len(steps))
+	for _, step := range steps {
+		excluded := make([]excludedCandidateJSON, 0, len(step.Excluded))
+		for _, candidate := range step.Excluded {
+			excluded = append(excluded, excludedCandidateJSON{UserID: candidate.UserID, Reason: string(candidate.Reason)})
+		}
+		var inputs *selectionInputsJSON
+		if step.Inputs != nil {
+			inputs = &selectionInputsJSON{
+				Pool:          step.Inputs.Pool,
+				MaxReviewers:  step.Inputs.MaxReviewers,
+				Weights:       step.Inputs.Weights,
+				Preferred:     step.Inputs.Preferred,
+				CursorID:      step.Inputs.CursorID,
+				Loads:         step.Inputs.Loads,
+				RecentReviews: step.Inputs.RecentReviews,
+			}
+		}
+		records = append(records, selectionStepJSON{
+			Source:     string(step.Source),
+			TeamName:   step.TeamName,
+			Strategy:   step.Strategy,
+			Candidates: step.Candidates,
+			Excluded:   excluded,
+			Selected:   step.Selected,
+			Inputs:     inputs,
+		})
+	}
+	return json.Marshal(records)
+}
+
+// unmarshalSteps разбирает трассировку выбора из JSON
+func unmarshalSteps(data []byte) ([]*domain.SelectionStep, error) {
+	var records []selectionStepJSON
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	steps := make([]*domain.SelectionStep, 0, len(records))
+	for _, record := range records {
+		excluded := make([]*domain.ExcludedCandidate, 0, len(record.Excluded))
+		for _, candidate := range record.Excluded {
+			excluded = append(excluded, &domain.ExcludedCandidate{
+				UserID: candidate.UserID,
+				Reason: domain.ExclusionReason(candidate.Reason),
+			})
+		}
+		var inputs *domain.SelectionInputs
+		if record.Inputs != nil {
+			inputs = &domain.SelectionInputs{
+				Pool:          record.Inputs.Pool,
+				MaxReviewers:  record.Inputs.MaxReviewers,
+				Weights:       record.Inputs.Weights,
+				Preferred:     record.Inputs.Preferred,
+				CursorID:      record.Inputs.CursorID,
+				Loads:         record.Inputs.Loads,
+				RecentReviews: record.Inputs.RecentReviews,
+			}
+		}
+		steps = append(steps, &domain.SelectionStep{
+			Source:     domain.SelectionSource(record.Source),
+			TeamName:   record.TeamName,
+			Strategy:   record.Strategy,
+			Candidates: record.Candidates,
+			Excluded:   excluded,
+			Selected:   record.Selected,
+			Inputs:     inputs,
+		})
+	}
+	return steps, nil
+}
@@ -85,6 +85,81 @@ func TestPullRequestRepository_Create(t *testing.T) {
 		assert.NoError(t, err, "не все ожидания SQL-запросов были выполнены")
 	})
 
+	t.Run("успешное создание PR с тегами", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		pr := &domain.PullRequest{
+			ID:                "pr-1001",
+			Title:             "Test PR",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2"},
+			Tags:              []string{"go", "sql"},
+		}
+
+		mock.ExpectQuery("SELECT id FROM statuses WHERE name = \\$1").
+			WithArgs("OPEN").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery("INSERT INTO pull_requests").
+			WithArgs(1001, "Test PR", 1, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1001, time.Now(), nil))
+		mock.ExpectExec("SELECT setval").
+			WithArgs(1001).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO pull_request_reviewers").
+			WithArgs(1001, 2, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO pull_request_tags").
+			WithArgs(1001, "go").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO pull_request_tags").
+			WithArgs(1001, "sql").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Create(context.Background(), pr)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("успешное создание PR с соавторами", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		pr := &domain.PullRequest{
+			ID:                "pr-1001",
+			Title:             "Test PR",
+			AuthorID:          "u1",
+			CoAuthorIDs:       []string{"u4"},
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}
+
+		mock.ExpectQuery("SELECT id FROM statuses WHERE name = \\$1").
+			WithArgs("OPEN").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery("INSERT INTO pull_requests").
+			WithArgs(1001, "Test PR", 1, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1001, time.Now(), nil))
+		mock.ExpectExec("SELECT setval").
+			WithArgs(1001).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO pull_request_reviewers").
+			WithArgs(1001, 2, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO pull_request_co_authors").
+			WithArgs(1001, 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Create(context.Background(), pr)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
 	t.Run("успешное создание PR без ревьюверов", func(t *testing.T) {
 
 		repo, mock := setupPRRepo(t)
@@ -589,7 +664,7 @@ func TestPullRequestRepository_UpdateStatus(t *testing.T) {
 
 		updateRows := sqlmock.NewRows([]string{"id"}).AddRow(1001)
 		mock.ExpectQuery("UPDATE pull_requests").
-			WithArgs(1001, 2, sqlmock.AnyArg()).
+			WithArgs(1001, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnRows(updateRows)
 
 		err := repo.UpdateStatus(context.Background(), "pr-1001", domain.StatusMerged, nil)
@@ -612,7 +687,7 @@ func TestPullRequestRepository_UpdateStatus(t *testing.T) {
 
 		updateRows := sqlmock.NewRows([]string{"id"}).AddRow(1001)
 		mock.ExpectQuery("UPDATE pull_requests").
-			WithArgs(1001, 2, sqlmock.AnyArg()).
+			WithArgs(1001, 2, mergedAt, mergedAt, nil).
 			WillReturnRows(updateRows)
 
 		err := repo.UpdateStatus(context.Background(), "pr-1001", domain.StatusMerged, &mergedAt)
@@ -632,7 +707,7 @@ func TestPullRequestRepository_UpdateStatus(t *testing.T) {
 			WillReturnRows(statusRows)
 
 		mock.ExpectQuery("UPDATE pull_requests").
-			WithArgs(9999, 2, sqlmock.AnyArg()).
+			WithArgs(9999, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnError(sql.ErrNoRows)
 
 		err := repo.UpdateStatus(context.Background(), "pr-9999", domain.StatusMerged, nil)
@@ -669,7 +744,7 @@ func TestPullRequestRepository_UpdateStatus(t *testing.T) {
 
 		updateRows := sqlmock.NewRows([]string{"id"}).AddRow(1001)
 		mock.ExpectQuery("UPDATE pull_requests").
-			WithArgs(1001, 1, sqlmock.AnyArg()).
+			WithArgs(1001, 1, sqlmock.AnyArg(), nil, nil).
 			WillReturnRows(updateRows)
 
 		err := repo.UpdateStatus(context.Background(), "pr-1001", domain.StatusOpen, nil)
@@ -682,7 +757,7 @@ func TestPullRequestRepository_UpdateStatus(t *testing.T) {
 
 		updateRows2 := sqlmock.NewRows([]string{"id"}).AddRow(1001)
 		mock.ExpectQuery("UPDATE pull_requests").
-			WithArgs(1001, 1, sqlmock.AnyArg()).
+			WithArgs(1001, 1, sqlmock.AnyArg(), nil, nil).
 			WillReturnRows(updateRows2)
 
 		err = repo.UpdateStatus(context.Background(), "pr-1001", domain.StatusOpen, nil)
@@ -693,6 +768,26 @@ func TestPullRequestRepository_UpdateStatus(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("закрытие сохраняет closed_at, merged_at остается пустым", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		closedAt := time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery("SELECT id FROM statuses WHERE name = \\$1").
+			WithArgs("CLOSED").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectQuery("UPDATE pull_requests").
+			WithArgs(1001, 4, closedAt, nil, closedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1001))
+
+		err := repo.UpdateStatus(context.Background(), "pr-1001", domain.StatusClosed, &closedAt)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
 	t.Run("ошибка: невалидный ID PR", func(t *testing.T) {
 		repo, mock := setupPRRepo(t)
 
@@ -712,18 +807,18 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 		repo, mock := setupPRRepo(t)
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
-		updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+		mergedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 
-		prRows := sqlmock.NewRows([]string{"id", "title", "id", "name", "created_at", "updated_at"}).
-			AddRow(1001, "Test PR", 1, "MERGED", createdAt, updatedAt)
-		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.updated_at").
+		prRows := sqlmock.NewRows([]string{"id", "title", "id", "name", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}).
+			AddRow(1001, "Test PR", 1, "MERGED", createdAt, mergedAt, nil, "go,sql", "4,5", "2:APPROVED,3:COMMENTED,7:CHANGES_REQUESTED")
+		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").
 			WithArgs(1001).
 			WillReturnRows(prRows)
 
-		reviewerRows := sqlmock.NewRows([]string{"id"}).
-			AddRow(2).
-			AddRow(3)
-		mock.ExpectQuery("SELECT u.id").
+		reviewerRows := sqlmock.NewRows([]string{"reviewer_id", "name"}).
+			AddRow(2, "backend").
+			AddRow(3, "frontend")
+		mock.ExpectQuery("SELECT prr.reviewer_id, COALESCE\\(t.name, ''\\)").
 			WithArgs(1001).
 			WillReturnRows(reviewerRows)
 
@@ -736,8 +831,15 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 		assert.Equal(t, "u1", pr.AuthorID)
 		assert.Equal(t, domain.StatusMerged, pr.Status)
 		assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+		assert.Equal(t, map[string]string{"u2": "backend", "u3": "frontend"}, pr.ReviewerTeams)
+		assert.Equal(t, []string{"go", "sql"}, pr.Tags)
+		assert.Equal(t, []string{"u4", "u5"}, pr.CoAuthorIDs)
+		assert.Equal(t, map[string]domain.ReviewState{"u2": domain.ReviewApproved, "u3": domain.ReviewCommented}, pr.ReviewStates,
+			"решения снятых с PR ревьюверов не возвращаются")
 		assert.NotNil(t, pr.CreatedAt)
-		assert.NotNil(t, pr.MergedAt)
+		require.NotNil(t, pr.MergedAt)
+		assert.Equal(t, mergedAt, *pr.MergedAt)
+		assert.Nil(t, pr.ClosedAt)
 
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err)
@@ -748,14 +850,14 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 
-		prRows := sqlmock.NewRows([]string{"id", "title", "id", "name", "created_at", "updated_at"}).
-			AddRow(1001, "Test PR", 1, "OPEN", createdAt, nil)
-		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.updated_at").
+		prRows := sqlmock.NewRows([]string{"id", "title", "id", "name", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}).
+			AddRow(1001, "Test PR", 1, "OPEN", createdAt, nil, nil, "", "", "")
+		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").
 			WithArgs(1001).
 			WillReturnRows(prRows)
 
-		reviewerRows := sqlmock.NewRows([]string{"id"})
-		mock.ExpectQuery("SELECT u.id").
+		reviewerRows := sqlmock.NewRows([]string{"reviewer_id", "name"})
+		mock.ExpectQuery("SELECT prr.reviewer_id").
 			WithArgs(1001).
 			WillReturnRows(reviewerRows)
 
@@ -768,6 +870,8 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 			assert.Len(t, pr.AssignedReviewers, 0)
 		}
 		assert.Nil(t, pr.MergedAt)
+		assert.Empty(t, pr.Tags)
+		assert.Empty(t, pr.CoAuthorIDs)
 
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err)
@@ -776,7 +880,7 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 	t.Run("ошибка: PR не найден", func(t *testing.T) {
 		repo, mock := setupPRRepo(t)
 
-		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.updated_at").
+		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").
 			WillReturnError(sql.ErrNoRows)
 
 		pr, err := repo.GetByID(context.Background(), "pr-9999")
@@ -803,6 +907,49 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestPullRequestRepository_GetByIDForUpdate(t *testing.T) {
+	t.Run("строка PR блокируется перед чтением", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		mock.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").
+			WithArgs(1001).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1001))
+		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").
+			WithArgs(1001).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "id", "name", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}).
+				AddRow(1001, "Test PR", 1, "OPEN", time.Now(), nil, nil, "", "", "2:APPROVED"))
+		mock.ExpectQuery("SELECT prr.reviewer_id").
+			WithArgs(1001).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+
+		pr, err := repo.GetByIDForUpdate(context.Background(), "pr-1001")
+
+		require.NoError(t, err)
+		assert.Equal(t, "pr-1001", pr.ID)
+		assert.Equal(t, map[string]domain.ReviewState{"u2": domain.ReviewApproved}, pr.ReviewStates)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: PR не найден", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		mock.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").
+			WithArgs(9999).
+			WillReturnError(sql.ErrNoRows)
+
+		pr, err := repo.GetByIDForUpdate(context.Background(), "pr-9999")
+
+		require.Error(t, err)
+		assert.Nil(t, pr)
+		assert.Equal(t, "pull request not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
 // TestPullRequestRepository_AddReviewer - тест для метода AddReviewer()
 func TestPullRequestRepository_AddReviewer(t *testing.T) {
 	t.Run("успешное добавление ревьювера", func(t *testing.T) {
@@ -1000,3 +1147,374 @@ func TestPullRequestRepository_GetPRsByReviewerID(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+// TestPullRequestRepository_GetOpenReviewCountsByTeamID - тест для метода GetOpenReviewCountsByTeamID()
+func TestPullRequestRepository_GetAwaitingReviewPRs(t *testing.T) {
+	t.Run("успешное получение OPEN PR без решения ревьювера", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		prRows := sqlmock.NewRows([]string{"id", "title", "id", "name"}).
+			AddRow(1003, "PR 3", 3, "OPEN")
+		mock.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name").
+			WithArgs(2, "OPEN", "APPROVED", "CHANGES_REQUESTED").
+			WillReturnRows(prRows)
+
+		prs, err := repo.GetAwaitingReviewPRs(context.Background(), "u2")
+
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, "pr-1003", prs[0].ID)
+		assert.Equal(t, "u3", prs[0].AuthorID)
+		assert.Equal(t, domain.StatusOpen, prs[0].Status)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: невалидный ID ревьювера", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		prs, err := repo.GetAwaitingReviewPRs(context.Background(), "invalid")
+
+		require.Error(t, err)
+		assert.Nil(t, prs)
+		assert.Equal(t, "invalid reviewer ID", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestPullRequestRepository_List(t *testing.T) {
+	columns := []string{"id", "title", "author_id", "name", "created_at", "merged_at", "closed_at", "tags", "co_authors", "reviewers", "review_states"}
+
+	t.Run("фильтры и курсор передаются в запрос", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		createdAt := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+		from := createdAt.Add(-24 * time.Hour)
+		// Курсор сравнивается с TIMESTAMP без часового пояса: передаются его дата и время как есть
+		after := time.Date(2024, 7, 1, 13, 0, 0, 500000000, time.FixedZone("MSK", 3*60*60))
+		rows := sqlmock.NewRows(columns).
+			AddRow(1002, "PR 2", 1, "OPEN", createdAt, nil, nil, "go,sql", "4", []byte(`[{"id": 3, "team": "backend"}, {"id": 2, "team": ""}]`), "2:APPROVED").
+			AddRow(1001, "PR 1", 1, "OPEN", createdAt, nil, nil, "", "", []byte(`[]`), "")
+		mock.ExpectQuery(`SELECT pr.id, pr.title, pr.author_id, s.name(.|\n)*WHERE s.name = \$1 AND pr.author_id = \$2 AND pr.created_at >= \$3::timestamp AND \(pr.created_at, pr.id\) < \(\$4::timestamp, \$5\)(.|\n)*ORDER BY pr.created_at DESC, pr.id DESC(.|\n)*LIMIT \$6`).
+			WithArgs("OPEN", 1, from.In(time.Local).Format("2006-01-02 15:04:05.999999"), "2024-07-01 13:00:00.5", 1003, 3).
+			WillReturnRows(rows)
+
+		prs, err := repo.List(context.Background(), domain.PullRequestFilter{
+			Status:      domain.StatusOpen,
+			AuthorID:    "u1",
+			CreatedFrom: &from,
+			After:       &domain.PullRequestCursor{CreatedAt: after, PullRequestID: "pr-1003"},
+			Limit:       3,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, prs, 2)
+		assert.Equal(t, "pr-1002", prs[0].ID)
+		assert.Equal(t, "u1", prs[0].AuthorID)
+		assert.Equal(t, []string{"u3", "u2"}, prs[0].AssignedReviewers)
+		assert.Equal(t, map[string]string{"u3": "backend"}, prs[0].ReviewerTeams)
+		assert.Equal(t, []string{"go", "sql"}, prs[0].Tags)
+		assert.Equal(t, []string{"u4"}, prs[0].CoAuthorIDs)
+		assert.Equal(t, domain.ReviewApproved, prs[0].ReviewStates["u2"])
+		assert.Empty(t, prs[1].AssignedReviewers)
+		assert.Empty(t, prs[1].ReviewerTeams)
+		assert.Equal(t, []string{}, prs[1].Tags)
+		assert.Equal(t, []string{}, prs[1].CoAuthorIDs)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("границы периода со смещением переводятся в пояс хранения", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		msk := time.FixedZone("MSK", 3*60*60)
+		from := time.Date(2024, 7, 1, 15, 0, 0, 0, msk)
+		to := time.Date(2024, 7, 2, 3, 0, 0, 0, msk)
+		// Create записывает created_at в локальном поясе приложения, поэтому 15:00+03:00 сравнивается с 12:00 UTC в этом поясе
+		expectedFrom := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC).In(time.Local).Format("2006-01-02 15:04:05.999999")
+		expectedTo := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC).In(time.Local).Format("2006-01-02 15:04:05.999999")
+		mock.ExpectQuery(`WHERE pr.created_at >= \$1::timestamp AND pr.created_at < \$2::timestamp(.|\n)*LIMIT \$3`).
+			WithArgs(expectedFrom, expectedTo, 21).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		prs, err := repo.List(context.Background(), domain.PullRequestFilter{CreatedFrom: &from, CreatedTo: &to, Limit: 21})
+
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("сортировка по возрастанию без фильтров", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		mock.ExpectQuery(`ORDER BY pr.created_at ASC, pr.id ASC`).
+			WithArgs(21).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		prs, err := repo.List(context.Background(), domain.PullRequestFilter{Ascending: true, Limit: 21})
+
+		require.NoError(t, err)
+		assert.NotNil(t, prs)
+		assert.Empty(t, prs)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: невалидный ID ревьювера", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		prs, err := repo.List(context.Background(), domain.PullRequestFilter{ReviewerID: "invalid", Limit: 21})
+
+		require.Error(t, err)
+		assert.Nil(t, prs)
+		assert.Equal(t, "invalid reviewer ID", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestPullRequestRepository_CreateReview(t *testing.T) {
+	t.Run("успешное сохранение решения", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		createdAt := time.Now()
+		mock.ExpectQuery("INSERT INTO pull_request_reviews").
+			WithArgs(1001, 2, "APPROVED", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, createdAt))
+
+		review := &domain.Review{PullRequestID: "pr-1001", ReviewerID: "u2", State: domain.ReviewApproved}
+		err := repo.CreateReview(context.Background(), review)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, review.ID)
+		assert.Equal(t, createdAt, review.CreatedAt)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestPullRequestRepository_CreateMergeOverride(t *testing.T) {
+	t.Run("успешная запись merge в обход политики", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		createdAt := time.Now()
+		mock.ExpectQuery("INSERT INTO pull_request_merge_overrides").
+			WithArgs(1001, "hotfix", "0 of 2 required approvals", 0, 2, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, createdAt))
+
+		override := &domain.MergeOverride{
+			PullRequestID:     "pr-1001",
+			Reason:            "hotfix",
+			Violation:         "0 of 2 required approvals",
+			RequiredApprovals: 2,
+		}
+		err := repo.CreateMergeOverride(context.Background(), override)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, override.ID)
+		assert.Equal(t, createdAt, override.CreatedAt)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestPullRequestRepository_GetOpenReviewCountsByTeamID(t *testing.T) {
+	t.Run("успешное получение нагрузки участников команды", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		rows := sqlmock.NewRows([]string{"id", "count"}).
+			AddRow(1, 0).
+			AddRow(2, 3)
+		mock.ExpectQuery("SELECT u.id, COUNT\\(pr.id\\)").
+			WithArgs(1, "OPEN").
+			WillReturnRows(rows)
+
+		counts, err := repo.GetOpenReviewCountsByTeamID(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"u1": 0, "u2": 3}, counts)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка БД", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		mock.ExpectQuery("SELECT u.id, COUNT\\(pr.id\\)").
+			WithArgs(1, "OPEN").
+			WillReturnError(errors.New("connection refused"))
+
+		counts, err := repo.GetOpenReviewCountsByTeamID(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.Nil(t, counts)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestPullRequestRepository_GetRecentReviewCounts - тест для метода GetRecentReviewCounts()
+func TestPullRequestRepository_GetRecentReviewCounts(t *testing.T) {
+	t.Run("успешный подсчет ревью последних PR автора", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		rows := sqlmock.NewRows([]string{"reviewer_id", "count"}).
+			AddRow(2, 3).
+			AddRow(4, 1)
+		mock.ExpectQuery("SELECT prr.reviewer_id, COUNT\\(\\*\\)").
+			WithArgs(1, 5).
+			WillReturnRows(rows)
+
+		counts, err := repo.GetRecentReviewCounts(context.Background(), "u1", 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"u2": 3, "u4": 1}, counts)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: невалидный ID автора", func(t *testing.T) {
+		repo, _ := setupPRRepo(t)
+
+		counts, err := repo.GetRecentReviewCounts(context.Background(), "invalid", 5)
+
+		require.Error(t, err)
+		assert.Nil(t, counts)
+		assert.Equal(t, "invalid author ID", err.Error())
+	})
+
+	t.Run("ошибка БД", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		mock.ExpectQuery("SELECT prr.reviewer_id, COUNT\\(\\*\\)").
+			WithArgs(1, 5).
+			WillReturnError(errors.New("connection refused"))
+
+		counts, err := repo.GetRecentReviewCounts(context.Background(), "u1", 5)
+
+		require.Error(t, err)
+		assert.Nil(t, counts)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestPullRequestRepository_Assignments - тест для методов CreateAssignment() и GetAssignments()
+func TestPullRequestRepository_Assignments(t *testing.T) {
+	t.Run("сохранение выбора с заменой ревьювера", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		createdAt := time.Now()
+		mock.ExpectQuery("INSERT INTO pull_request_assignments").
+			WithArgs(1, "REASSIGN", int64(-42), int64(2), []byte(`["u2","u4"]`), "", []byte(`[]`), []byte(`["u3"]`),
+				[]byte(`[{"source":"TEAM","team_name":"backend","strategy":"random","candidates":["u3"],`+
+					`"excluded":[{"user_id":"u1","reason":"AUTHOR"}],"selected":["u3"],`+
+					`"inputs":{"pool":["u1","u3"],"max_reviewers":1,"weights":{"u3":0.5},"recent_reviews":{"u3":1}}}]`),
+				sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+
+		assignment := &domain.Assignment{
+			PullRequestID:      "pr-1",
+			Event:              domain.AssignmentReassign,
+			Seed:               -42,
+			ReplacedReviewerID: "u2",
+			PreviousReviewers:  []string{"u2", "u4"},
+			SelectedReviewers:  []string{"u3"},
+			Steps: []*domain.SelectionStep{{
+				Source:     domain.SourceTeam,
+				TeamName:   "backend",
+				Strategy:   "random",
+				Candidates: []string{"u3"},
+				Excluded:   []*domain.ExcludedCandidate{{UserID: "u1", Reason: domain.ExclusionAuthor}},
+				Selected:   []string{"u3"},
+				Inputs: &domain.SelectionInputs{
+					Pool:          []string{"u1", "u3"},
+					MaxReviewers:  1,
+					Weights:       map[string]float64{"u3": 0.5},
+					RecentReviews: map[string]int{"u3": 1},
+				},
+			}},
+		}
+		err := repo.CreateAssignment(context.Background(), assignment)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, assignment.ID)
+		assert.Equal(t, createdAt, assignment.CreatedAt)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("получение истории выбора", func(t *testing.T) {
+		repo, mock := setupPRRepo(t)
+
+		createdAt := time.Now()
+		rows := sqlmock.NewRows([]string{
+			"id", "event", "seed", "replaced_reviewer_id", "previous_reviewers",
+			"repository", "changed_files", "selected_reviewers", "steps", "created_at",
+		}).
+			AddRow(1, "CREATE", int64(123), nil, []byte(`[]`), "backend", []byte(`["cmd/app/main.go"]`), []byte(`["u2","u4"]`),
+				[]byte(`[{"source":"CODEOWNERS","team_name":"backend","strategy":"round_robin","candidates":["u2"],"excluded":[],"selected":["u2"]},`+
+					`{"source":"TEAM","team_name":"backend","strategy":"round_robin","candidates":["u4"],`+
+					`"excluded":[{"user_id":"u3","reason":"UNAVAILABLE"}],"selected":["u4"],`+
+					`"inputs":{"pool":["u1","u2","u3","u4"],"max_reviewers":1,"weights":{"u4":1},"cursor_id":"u2"}}]`),
+				createdAt).
+			AddRow(2, "REASSIGN", int64(-5), 2, []byte(`["u2","u4"]`), "", []byte(`[]`), []byte(`["u3"]`), []byte(`[]`), createdAt)
+		mock.ExpectQuery("SELECT id, event, seed, replaced_reviewer_id").
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		assignments, err := repo.GetAssignments(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, assignments, 2)
+		assert.Equal(t, domain.AssignmentCreate, assignments[0].Event)
+		assert.Equal(t, int64(123), assignments[0].Seed)
+		assert.Empty(t, assignments[0].ReplacedReviewerID)
+		assert.Equal(t, "backend", assignments[0].Repository)
+		assert.Equal(t, []string{"cmd/app/main.go"}, assignments[0].ChangedFiles)
+		assert.Equal(t, []string{"u2", "u4"}, assignments[0].SelectedReviewers)
+		require.Len(t, assignments[0].Steps, 2)
+		assert.Equal(t, domain.SourceCodeOwners, assignments[0].Steps[0].Source)
+		assert.Equal(t, "round_robin", assignments[0].Steps[1].Strategy)
+		assert.Equal(t, []*domain.ExcludedCandidate{{UserID: "u3", Reason: domain.ExclusionUnavailable}}, assignments[0].Steps[1].Excluded)
+		assert.Nil(t, assignments[0].Steps[0].Inputs, "шаг, сохраненный без входных данных")
+		assert.Equal(t, &domain.SelectionInputs{
+			Pool:         []string{"u1", "u2", "u3", "u4"},
+			MaxReviewers: 1,
+			Weights:      map[string]float64{"u4": 1},
+			CursorID:     "u2",
+		}, assignments[0].Steps[1].Inputs)
+		assert.Empty(t, assignments[1].Steps)
+		assert.Equal(t, "u2", assignments[1].ReplacedReviewerID)
+		assert.Equal(t, []string{"u2", "u4"}, assignments[1].PreviousReviewers)
+		assert.Equal(t, []string{"u3"}, assignments[1].SelectedReviewers)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: некорректный ID PR", func(t *testing.T) {
+		repo, _ := setupPRRepo(t)
+
+		assignments, err := repo.GetAssignments(context.Background(), "pr-abc")
+
+		require.Error(t, err)
+		assert.Nil(t, assignments)
+		assert.Equal(t, "invalid pull request ID", err.Error())
+	})
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type rotationRepository struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+func NewRotationRepository(db *sql.DB) *rotationRepository {
+	return &rotationRepository{db: db}
+}
+
+// NewRotationRepositoryWithTx создает репозиторий, работающий в транзакции tx.
+// Блокировка курсора держится, а его сдвиг фиксируется или откатывается вместе с tx.
+func NewRotationRepositoryWithTx(tx *sql.Tx) *rotationRepository {
+	return &rotationRepository{tx: tx}
+}
+
+// executor возвращает транзакцию репозитория, если она задана, иначе подключение к БД
+func (r *rotationRepository) executor() DBExecutor {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// AdvanceCursor блокирует курсор ротации команды на время вызова next и сохраняет возвращенный им курсор.
+// Параллельные вызовы для одной команды выполняются последовательно благодаря SELECT ... FOR UPDATE.
+// Без внешней транзакции сдвиг выполняется в собственной транзакции.
+func (r *rotationRepository) AdvanceCursor(ctx context.Context, teamID int, next func(cursorID string) (string, error)) error {
+	if r.tx != nil {
+		return advanceCursor(ctx, r.tx, teamID, next)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := advanceCursor(ctx, tx, teamID, next); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func advanceCursor(ctx context.Context, executor DBExecutor, teamID int, next func(cursorID string) (string, error)) error {
+	_, err := executor.ExecContext(
+		ctx,
+		"INSERT INTO team_rotations (team_id) VALUES ($1) ON CONFLICT (team_id) DO NOTHING",
+		teamID,
+	)
+	if err != nil {
+		return err
+	}
+
+	var lastReviewerDBID sql.NullInt64
+	err = executor.QueryRowContext(
+		ctx,
+		"SELECT last_reviewer_id FROM team_rotations WHERE team_id = $1 FOR UPDATE",
+		teamID,
+	).Scan(&lastReviewerDBID)
+	if err != nil {
+		return err
+	}
+
+	cursorID := ""
+	if lastReviewerDBID.Valid {
+		cursorID = intToStringID(int(lastReviewerDBID.Int64))
+	}
+
+	newCursorID, err := next(cursorID)
+	if err != nil {
+		return err
+	}
+
+	var newCursor sql.NullInt64
+	if newCursorID != "" {
+		newCursorDBID, err := stringIDToInt(newCursorID)
+		if err != nil {
+			return errors.New("invalid reviewer ID")
+		}
+		newCursor = sql.NullInt64{Int64: int64(newCursorDBID), Valid: true}
+	}
+
+	_, err = executor.ExecContext(
+		ctx,
+		"UPDATE team_rotations SET last_reviewer_id = $2, updated_at = $3 WHERE team_id = $1",
+		teamID,
+		newCursor,
+		time.Now(),
+	)
+	return err
+}
+
+// GetCursor возвращает текущий курсор ротации команды без блокировки и изменения.
+// Для команды, у которой ротации еще не было, возвращается пустая строка.
+func (r *rotationRepository) GetCursor(ctx context.Context, teamID int) (string, error) {
+	var lastReviewerDBID sql.NullInt64
+	err := r.executor().QueryRowContext(
+		ctx,
+		"SELECT last_reviewer_id FROM team_rotations WHERE team_id = $1",
+		teamID,
+	).Scan(&lastReviewerDBID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if !lastReviewerDBID.Valid {
+		return "", nil
+	}
+	return intToStringID(int(lastReviewerDBID.Int64)), nil
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRotationRepo создает мок БД и репозиторий для курсоров ротации
+func setupRotationRepo(t *testing.T) (*rotationRepository, sqlmock.Sqlmock) {
+	db, mock := setupMockDB(t)
+	return NewRotationRepository(db), mock
+}
+
+// TestRotationRepository_AdvanceCursor - тест для метода AdvanceCursor()
+// Курсор блокируется через SELECT ... FOR UPDATE в транзакции, чтобы параллельные
+// создания PR не выбирали одних и тех же ревьюверов
+func TestRotationRepository_AdvanceCursor(t *testing.T) {
+	t.Run("успешный сдвиг курсора", func(t *testing.T) {
+		repo, mock := setupRotationRepo(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO team_rotations").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT last_reviewer_id FROM team_rotations WHERE team_id = \\$1 FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}).AddRow(2))
+		mock.ExpectExec("UPDATE team_rotations").
+			WithArgs(1, int64(3), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var gotCursor string
+		err := repo.AdvanceCursor(context.Background(), 1, func(cursorID string) (string, error) {
+			gotCursor = cursorID
+			return "u3", nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "u2", gotCursor)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("сдвиг курсора во внешней транзакции", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+
+		mock.ExpectBegin()
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		repo := NewRotationRepositoryWithTx(tx)
+
+		mock.ExpectExec("INSERT INTO team_rotations").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT last_reviewer_id FROM team_rotations WHERE team_id = \\$1 FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}).AddRow(2))
+		mock.ExpectExec("UPDATE team_rotations").
+			WithArgs(1, int64(3), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		err = repo.AdvanceCursor(context.Background(), 1, func(cursorID string) (string, error) {
+			return "u3", nil
+		})
+		require.NoError(t, err)
+
+		// сдвиг не фиксируется сам и откатывается вместе с внешней транзакцией
+		require.NoError(t, tx.Rollback())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("первый сдвиг курсора новой команды", func(t *testing.T) {
+		repo, mock := setupRotationRepo(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO team_rotations").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT last_reviewer_id FROM team_rotations").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}).AddRow(nil))
+		mock.ExpectExec("UPDATE team_rotations").
+			WithArgs(1, int64(1), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var gotCursor string
+		err := repo.AdvanceCursor(context.Background(), 1, func(cursorID string) (string, error) {
+			gotCursor = cursorID
+			return "u1", nil
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, gotCursor, "курсор новой команды должен быть пустым")
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка в next откатывает транзакцию", func(t *testing.T) {
+		repo, mock := setupRotationRepo(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO team_rotations").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT last_reviewer_id FROM team_rotations").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}).AddRow(2))
+		mock.ExpectRollback()
+
+		err := repo.AdvanceCursor(context.Background(), 1, func(cursorID string) (string, error) {
+			return "", errors.New("selection failed")
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, "selection failed", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestRotationRepository_GetCursor - тест для метода GetCursor()
+func TestRotationRepository_GetCursor(t *testing.T) {
+	t.Run("курсор читается без блокировки", func(t *testing.T) {
+		repo, mock := setupRotationRepo(t)
+
+		mock.ExpectQuery("SELECT last_reviewer_id FROM team_rotations WHERE team_id = \\$1$").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}).AddRow(2))
+
+		cursorID, err := repo.GetCursor(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "u2", cursorID)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("у команды еще не было ротации", func(t *testing.T) {
+		repo, mock := setupRotationRepo(t)
+
+		mock.ExpectQuery("SELECT last_reviewer_id FROM team_rotations").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}))
+
+		cursorID, err := repo.GetCursor(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, cursorID)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
@@ -45,6 +45,37 @@ func (r *statsRepository) GetReviewerStats(ctx context.Context) ([]*domain.Revie
 	return stats, rows.Err()
 }
 
+// GetAuthorStats считает для каждого пользователя PR, где он автор, и PR, где он соавтор
+func (r *statsRepository) GetAuthorStats(ctx context.Context) ([]*domain.AuthorStat, error) {
+	query := `
+		SELECT u.id, u.name,
+			(SELECT COUNT(*) FROM pull_requests pr WHERE pr.author_id = u.id) AS authored_count,
+			(SELECT COUNT(*) FROM pull_request_co_authors pca WHERE pca.user_id = u.id) AS co_authored_count
+		FROM users u
+		ORDER BY authored_count + co_authored_count DESC, u.id
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*domain.AuthorStat
+	for rows.Next() {
+		stat := &domain.AuthorStat{}
+		var userDBID int
+		err := rows.Scan(&userDBID, &stat.Username, &stat.AuthoredCount, &stat.CoAuthoredCount)
+		if err != nil {
+			return nil, err
+		}
+		stat.UserID = intToStringID(userDBID)
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
 func (r *statsRepository) GetPRStatsByStatus(ctx context.Context) ([]*domain.PRStatusStat, error) {
 	query := `
 		SELECT s.name as status, COUNT(pr.id) as count
@@ -23,8 +23,8 @@ func NewTeamRepositoryWithTx(tx *sql.Tx) *teamRepository {
 
 func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
 	query := `
-		INSERT INTO teams (name, created_at)
-		VALUES ($1, $2)
+		INSERT INTO teams (name, created_at, min_reviewers, max_reviewers)
+		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (name) DO UPDATE
 		SET updated_at = CURRENT_TIMESTAMP
 		RETURNING id, created_at, updated_at
@@ -33,7 +33,14 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
 	now := time.Now()
 	var teamID int
 	var updatedAt sql.NullTime
-	err := r.executor.QueryRowContext(ctx, query, team.Name, now).Scan(&teamID, &team.CreatedAt, &updatedAt)
+	err := r.executor.QueryRowContext(
+		ctx,
+		query,
+		team.Name,
+		now,
+		team.MinReviewers,
+		team.MaxReviewers,
+	).Scan(&teamID, &team.CreatedAt, &updatedAt)
 
 	if updatedAt.Valid {
 		team.UpdatedAt = &updatedAt.Time
@@ -50,7 +57,7 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
 
 func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, min_reviewers, max_reviewers, created_at, updated_at
 		FROM teams
 		WHERE name = $1
 	`
@@ -60,6 +67,8 @@ func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Te
 	err := r.executor.QueryRowContext(ctx, query, name).Scan(
 		&team.ID,
 		&team.Name,
+		&team.MinReviewers,
+		&team.MaxReviewers,
 		&team.CreatedAt,
 		&updatedAt,
 	)
@@ -79,3 +88,172 @@ func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Te
 
 	return team, nil
 }
+
+func (r *teamRepository) UpdateSettings(ctx context.Context, teamID int, minReviewers, maxReviewers int) error {
+	query := `
+		UPDATE teams
+		SET min_reviewers = $2, max_reviewers = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	result, err := r.executor.ExecContext(ctx, query, teamID, minReviewers, maxReviewers, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("team not found")
+	}
+
+	return nil
+}
+
+// GetFallbackTeams возвращает резервные команды в порядке приоритета
+func (r *teamRepository) GetFallbackTeams(ctx context.Context, teamID int) ([]*domain.Team, error) {
+	query := `
+		SELECT t.id, t.name, t.min_reviewers, t.max_reviewers, t.created_at, t.updated_at
+		FROM team_fallbacks tf
+		JOIN teams t ON tf.fallback_team_id = t.id
+		WHERE tf.team_id = $1
+		ORDER BY tf.priority
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []*domain.Team
+	for rows.Next() {
+		team := &domain.Team{}
+		var updatedAt sql.NullTime
+		err := rows.Scan(
+			&team.ID,
+			&team.Name,
+			&team.MinReviewers,
+			&team.MaxReviewers,
+			&team.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if updatedAt.Valid {
+			team.UpdatedAt = &updatedAt.Time
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+// SetFallbackTeams заменяет список резервных команд; приоритет определяется порядком fallbackTeamIDs.
+// Должен вызываться в транзакции (репозиторий, созданный через NewTeamRepositoryWithTx).
+func (r *teamRepository) SetFallbackTeams(ctx context.Context, teamID int, fallbackTeamIDs []int) error {
+	_, err := r.executor.ExecContext(ctx, "DELETE FROM team_fallbacks WHERE team_id = $1", teamID)
+	if err != nil {
+		return err
+	}
+
+	for priority, fallbackTeamID := range fallbackTeamIDs {
+		_, err := r.executor.ExecContext(
+			ctx,
+			"INSERT INTO team_fallbacks (team_id, fallback_team_id, priority) VALUES ($1, $2, $3)",
+			teamID,
+			fallbackTeamID,
+			priority,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMandatoryReviewers возвращает ID обязательных ревьюверов команды
+func (r *teamRepository) GetMandatoryReviewers(ctx context.Context, teamID int) ([]string, error) {
+	rows, err := r.executor.QueryContext(
+		ctx,
+		"SELECT user_id FROM team_mandatory_reviewers WHERE team_id = $1 ORDER BY user_id",
+		teamID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userDBID int
+		if err := rows.Scan(&userDBID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, intToStringID(userDBID))
+	}
+
+	return userIDs, rows.Err()
+}
+
+// SetMandatoryReviewers заменяет список обязательных ревьюверов команды.
+// Должен вызываться в транзакции (репозиторий, созданный через NewTeamRepositoryWithTx).
+func (r *teamRepository) SetMandatoryReviewers(ctx context.Context, teamID int, userIDs []string) error {
+	_, err := r.executor.ExecContext(ctx, "DELETE FROM team_mandatory_reviewers WHERE team_id = $1", teamID)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		userDBID, err := stringIDToInt(userID)
+		if err != nil {
+			return errors.New("invalid user ID")
+		}
+
+		_, err = r.executor.ExecContext(
+			ctx,
+			"INSERT INTO team_mandatory_reviewers (team_id, user_id) VALUES ($1, $2)",
+			teamID,
+			userDBID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMergePolicy возвращает политику merge команды; для команды без политики - нулевое значение
+func (r *teamRepository) GetMergePolicy(ctx context.Context, teamID int) (domain.MergePolicy, error) {
+	var policy domain.MergePolicy
+	err := r.executor.QueryRowContext(
+		ctx,
+		"SELECT required_approvals, block_on_changes_requested FROM team_merge_policies WHERE team_id = $1",
+		teamID,
+	).Scan(&policy.RequiredApprovals, &policy.BlockOnChangesRequested)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.MergePolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// SetMergePolicy задает политику merge команды, заменяя прежнюю
+func (r *teamRepository) SetMergePolicy(ctx context.Context, teamID int, policy domain.MergePolicy) error {
+	query := `
+		INSERT INTO team_merge_policies (team_id, required_approvals, block_on_changes_requested)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_id) DO UPDATE
+		SET required_approvals = EXCLUDED.required_approvals,
+			block_on_changes_requested = EXCLUDED.block_on_changes_requested
+	`
+
+	_, err := r.executor.ExecContext(ctx, query, teamID, policy.RequiredApprovals, policy.BlockOnChangesRequested)
+	return err
+}
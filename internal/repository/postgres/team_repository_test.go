@@ -38,7 +38,7 @@ func TestTeamRepository_Create(t *testing.T) {
 		teamRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 			AddRow(1, now, nil)
 		mock.ExpectQuery("INSERT INTO teams").
-			WithArgs("Team Alpha", sqlmock.AnyArg()).
+			WithArgs("Team Alpha", sqlmock.AnyArg(), 0, 0).
 			WillReturnRows(teamRows)
 
 		err := repo.Create(context.Background(), team)
@@ -68,7 +68,7 @@ func TestTeamRepository_Create(t *testing.T) {
 		teamRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 			AddRow(1, now.Add(-7*24*time.Hour), updatedAt)
 		mock.ExpectQuery("INSERT INTO teams").
-			WithArgs("Existing Team", sqlmock.AnyArg()).
+			WithArgs("Existing Team", sqlmock.AnyArg(), 0, 0).
 			WillReturnRows(teamRows)
 
 		err := repo.Create(context.Background(), team)
@@ -93,7 +93,7 @@ func TestTeamRepository_Create(t *testing.T) {
 		teamRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 			AddRow(2, now, nil)
 		mock.ExpectQuery("INSERT INTO teams").
-			WithArgs("Empty Team", sqlmock.AnyArg()).
+			WithArgs("Empty Team", sqlmock.AnyArg(), 0, 0).
 			WillReturnRows(teamRows)
 
 		err := repo.Create(context.Background(), team)
@@ -121,7 +121,7 @@ func TestTeamRepository_Create(t *testing.T) {
 		teamRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 			AddRow(3, now, nil)
 		mock.ExpectQuery("INSERT INTO teams").
-			WithArgs("Large Team", sqlmock.AnyArg()).
+			WithArgs("Large Team", sqlmock.AnyArg(), 0, 0).
 			WillReturnRows(teamRows)
 
 		err := repo.Create(context.Background(), team)
@@ -143,7 +143,7 @@ func TestTeamRepository_Create(t *testing.T) {
 
 		expectedError := errors.New("database error")
 		mock.ExpectQuery("INSERT INTO teams").
-			WithArgs("Team", sqlmock.AnyArg()).
+			WithArgs("Team", sqlmock.AnyArg(), 0, 0).
 			WillReturnError(expectedError)
 
 		err := repo.Create(context.Background(), team)
@@ -175,7 +175,7 @@ func TestTeamRepository_Create(t *testing.T) {
 
 		expectedError := errors.New("connection failed")
 		mock.ExpectQuery("INSERT INTO teams").
-			WithArgs("Team", sqlmock.AnyArg()).
+			WithArgs("Team", sqlmock.AnyArg(), 0, 0).
 			WillReturnError(expectedError)
 
 		err := repo.Create(context.Background(), team)
@@ -193,9 +193,9 @@ func TestTeamRepository_GetByName(t *testing.T) {
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 		updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 
-		teamRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
-			AddRow(1, "Team Alpha", createdAt, updatedAt)
-		mock.ExpectQuery("SELECT id, name, created_at, updated_at").
+		teamRows := sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+			AddRow(1, "Team Alpha", 1, 3, createdAt, updatedAt)
+		mock.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers, created_at, updated_at").
 			WithArgs("Team Alpha").
 			WillReturnRows(teamRows)
 
@@ -205,6 +205,8 @@ func TestTeamRepository_GetByName(t *testing.T) {
 		assert.NotNil(t, team)
 		assert.Equal(t, 1, team.ID)
 		assert.Equal(t, "Team Alpha", team.Name)
+		assert.Equal(t, 1, team.MinReviewers)
+		assert.Equal(t, 3, team.MaxReviewers)
 		assert.NotNil(t, team.UpdatedAt)
 
 		err = mock.ExpectationsWereMet()
@@ -216,9 +218,9 @@ func TestTeamRepository_GetByName(t *testing.T) {
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 
-		teamRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
-			AddRow(1, "Empty Team", createdAt, nil)
-		mock.ExpectQuery("SELECT id, name, created_at, updated_at").
+		teamRows := sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+			AddRow(1, "Empty Team", 1, 2, createdAt, nil)
+		mock.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers, created_at, updated_at").
 			WithArgs("Empty Team").
 			WillReturnRows(teamRows)
 
@@ -236,7 +238,7 @@ func TestTeamRepository_GetByName(t *testing.T) {
 	t.Run("ошибка: команда не найдена", func(t *testing.T) {
 		repo, mock := setupTeamRepo(t)
 
-		mock.ExpectQuery("SELECT id, name, created_at, updated_at").
+		mock.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers, created_at, updated_at").
 			WithArgs("Non-existent Team").
 			WillReturnError(sql.ErrNoRows)
 
@@ -255,9 +257,9 @@ func TestTeamRepository_GetByName(t *testing.T) {
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 
-		teamRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
-			AddRow(1, "New Team", createdAt, nil)
-		mock.ExpectQuery("SELECT id, name, created_at, updated_at").
+		teamRows := sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+			AddRow(1, "New Team", 1, 2, createdAt, nil)
+		mock.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers, created_at, updated_at").
 			WithArgs("New Team").
 			WillReturnRows(teamRows)
 
@@ -273,3 +275,205 @@ func TestTeamRepository_GetByName(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+// TestTeamRepository_UpdateSettings - тест для метода UpdateSettings()
+func TestTeamRepository_UpdateSettings(t *testing.T) {
+	t.Run("успешное обновление настроек команды", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectExec("UPDATE teams").
+			WithArgs(1, 1, 3, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateSettings(context.Background(), 1, 1, 3)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: команда не найдена", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectExec("UPDATE teams").
+			WithArgs(999, 1, 3, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateSettings(context.Background(), 999, 1, 3)
+
+		require.Error(t, err)
+		assert.Equal(t, "team not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestTeamRepository_GetFallbackTeams - тест для метода GetFallbackTeams()
+func TestTeamRepository_GetFallbackTeams(t *testing.T) {
+	t.Run("успешное получение резервных команд в порядке приоритета", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		createdAt := time.Now()
+		mock.ExpectQuery("FROM team_fallbacks tf\\s+JOIN teams t ON tf.fallback_team_id = t.id\\s+WHERE tf.team_id = \\$1\\s+ORDER BY tf.priority").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+				AddRow(3, "mobile", 1, 2, createdAt, nil).
+				AddRow(2, "platform", 1, 2, createdAt, nil))
+
+		teams, err := repo.GetFallbackTeams(context.Background(), 1)
+
+		require.NoError(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, "mobile", teams[0].Name)
+		assert.Equal(t, "platform", teams[1].Name)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestTeamRepository_SetFallbackTeams - тест для метода SetFallbackTeams()
+func TestTeamRepository_SetFallbackTeams(t *testing.T) {
+	t.Run("резервные команды заменяются с приоритетом по порядку", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectExec("DELETE FROM team_fallbacks").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO team_fallbacks").WithArgs(1, 3, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO team_fallbacks").WithArgs(1, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetFallbackTeams(context.Background(), 1, []int{3, 2})
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("пустой список очищает резервные команды", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectExec("DELETE FROM team_fallbacks").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.SetFallbackTeams(context.Background(), 1, nil)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestTeamRepository_GetMandatoryReviewers - тест для метода GetMandatoryReviewers()
+func TestTeamRepository_GetMandatoryReviewers(t *testing.T) {
+	t.Run("успешное получение обязательных ревьюверов", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectQuery("SELECT user_id FROM team_mandatory_reviewers").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(4).AddRow(7))
+
+		userIDs, err := repo.GetMandatoryReviewers(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u7"}, userIDs)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("пустой список, если обязательных ревьюверов нет", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectQuery("SELECT user_id FROM team_mandatory_reviewers").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+		userIDs, err := repo.GetMandatoryReviewers(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, userIDs)
+		assert.NotNil(t, userIDs)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestTeamRepository_SetMandatoryReviewers - тест для метода SetMandatoryReviewers()
+func TestTeamRepository_SetMandatoryReviewers(t *testing.T) {
+	t.Run("обязательные ревьюверы заменяются", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectExec("DELETE FROM team_mandatory_reviewers").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO team_mandatory_reviewers").WithArgs(1, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetMandatoryReviewers(context.Background(), 1, []string{"u7"})
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: невалидный ID пользователя", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectExec("DELETE FROM team_mandatory_reviewers").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.SetMandatoryReviewers(context.Background(), 1, []string{"invalid"})
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid user ID", err.Error())
+	})
+}
+
+func TestTeamRepository_GetMergePolicy(t *testing.T) {
+	t.Run("успешное получение политики merge", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectQuery("SELECT required_approvals, block_on_changes_requested FROM team_merge_policies").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"required_approvals", "block_on_changes_requested"}).AddRow(2, true))
+
+		policy, err := repo.GetMergePolicy(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.MergePolicy{RequiredApprovals: 2, BlockOnChangesRequested: true}, policy)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("нулевая политика, если она не задана", func(t *testing.T) {
+		repo, mock := setupTeamRepo(t)
+
+		mock.ExpectQuery("SELECT required_approvals, block_on_changes_requested FROM team_merge_policies").
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+
+		policy, err := repo.GetMergePolicy(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.MergePolicy{}, policy)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestTeamRepository_SetMergePolicy(t *testing.T) {
+	repo, mock := setupTeamRepo(t)
+
+	mock.ExpectExec("INSERT INTO team_merge_policies").
+		WithArgs(1, 2, true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetMergePolicy(context.Background(), 1, domain.MergePolicy{RequiredApprovals: 2, BlockOnChangesRequested: true})
+
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
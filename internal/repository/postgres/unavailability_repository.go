@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+// unavailabilityRepository хранит границы периодов в колонках TIMESTAMP как время UTC и сравнивает их
+// с часами БД (NOW() AT TIME ZONE 'UTC'), поэтому часовые пояса приложения и сессии БД не влияют на результат
+type unavailabilityRepository struct {
+	executor DBExecutor
+}
+
+func NewUnavailabilityRepository(db *sql.DB) *unavailabilityRepository {
+	return &unavailabilityRepository{executor: db}
+}
+
+func (r *unavailabilityRepository) Create(ctx context.Context, unavailability *domain.Unavailability) error {
+	userDBID, err := stringIDToInt(unavailability.UserID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	query := `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err = r.executor.QueryRowContext(
+		ctx,
+		query,
+		userDBID,
+		unavailability.StartsAt.UTC(),
+		unavailability.EndsAt.UTC(),
+		unavailability.Reason,
+		time.Now(),
+	).Scan(&unavailability.ID, &unavailability.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	unavailability.ReviewsReassignedAt = nil
+	unavailability.UpdatedAt = nil
+
+	return nil
+}
+
+func (r *unavailabilityRepository) GetByID(ctx context.Context, id int) (*domain.Unavailability, error) {
+	query := `
+		SELECT id, user_id, starts_at, ends_at, reason, reviews_reassigned_at, created_at, updated_at
+		FROM user_unavailability
+		WHERE id = $1
+	`
+
+	unavailability, err := scanUnavailability(r.executor.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("unavailability not found")
+		}
+		return nil, err
+	}
+
+	return unavailability, nil
+}
+
+func (r *unavailabilityRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Unavailability, error) {
+	userDBID, err := stringIDToInt(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	query := `
+		SELECT id, user_id, starts_at, ends_at, reason, reviews_reassigned_at, created_at, updated_at
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at
+	`
+
+	return r.queryUnavailabilities(ctx, query, userDBID)
+}
+
+// Update изменяет период и причину отсутствия.
+// Отметка о переназначении ревью сбрасывается, чтобы фоновая задача обработала новый период.
+func (r *unavailabilityRepository) Update(ctx context.Context, unavailability *domain.Unavailability) error {
+	query := `
+		UPDATE user_unavailability
+		SET starts_at = $2, ends_at = $3, reason = $4, reviews_reassigned_at = NULL, updated_at = $5
+		WHERE id = $1
+	`
+
+	result, err := r.executor.ExecContext(
+		ctx,
+		query,
+		unavailability.ID,
+		unavailability.StartsAt.UTC(),
+		unavailability.EndsAt.UTC(),
+		unavailability.Reason,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("unavailability not found")
+	}
+
+	return nil
+}
+
+func (r *unavailabilityRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.executor.ExecContext(ctx, "DELETE FROM user_unavailability WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("unavailability not found")
+	}
+
+	return nil
+}
+
+func (r *unavailabilityRepository) GetStartedPending(ctx context.Context) ([]*domain.Unavailability, error) {
+	query := `
+		SELECT id, user_id, starts_at, ends_at, reason, reviews_reassigned_at, created_at, updated_at
+		FROM user_unavailability
+		WHERE reviews_reassigned_at IS NULL AND starts_at <= NOW() AT TIME ZONE 'UTC' AND ends_at > NOW() AT TIME ZONE 'UTC'
+		ORDER BY starts_at
+	`
+
+	return r.queryUnavailabilities(ctx, query)
+}
+
+func (r *unavailabilityRepository) MarkReviewsReassigned(ctx context.Context, id int) error {
+	result, err := r.executor.ExecContext(
+		ctx,
+		"UPDATE user_unavailability SET reviews_reassigned_at = NOW() AT TIME ZONE 'UTC' WHERE id = $1",
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("unavailability not found")
+	}
+
+	return nil
+}
+
+func (r *unavailabilityRepository) queryUnavailabilities(ctx context.Context, query string, args ...any) ([]*domain.Unavailability, error) {
+	rows, err := r.executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var unavailabilities []*domain.Unavailability
+	for rows.Next() {
+		unavailability, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, err
+		}
+		unavailabilities = append(unavailabilities, unavailability)
+	}
+
+	return unavailabilities, rows.Err()
+}
+
+// scanUnavailability читает строку user_unavailability из *sql.Row или *sql.Rows
+func scanUnavailability(row interface{ Scan(dest ...any) error }) (*domain.Unavailability, error) {
+	unavailability := &domain.Unavailability{}
+	var userDBID int
+	var reviewsReassignedAt, updatedAt sql.NullTime
+	err := row.Scan(
+		&unavailability.ID,
+		&userDBID,
+		&unavailability.StartsAt,
+		&unavailability.EndsAt,
+		&unavailability.Reason,
+		&reviewsReassignedAt,
+		&unavailability.CreatedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unavailability.UserID = intToStringID(userDBID)
+	if reviewsReassignedAt.Valid {
+		unavailability.ReviewsReassignedAt = &reviewsReassignedAt.Time
+	}
+	if updatedAt.Valid {
+		unavailability.UpdatedAt = &updatedAt.Time
+	}
+
+	return unavailability, nil
+}
@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupUnavailabilityRepo создает мок БД и репозиторий для периодов отсутствия
+func setupUnavailabilityRepo(t *testing.T) (*unavailabilityRepository, sqlmock.Sqlmock) {
+	db, mock := setupMockDB(t)
+	return NewUnavailabilityRepository(db), mock
+}
+
+var unavailabilityColumns = []string{"id", "user_id", "starts_at", "ends_at", "reason", "reviews_reassigned_at", "created_at", "updated_at"}
+
+// TestUnavailabilityRepository_Create - тест для метода Create()
+func TestUnavailabilityRepository_Create(t *testing.T) {
+	t.Run("успешное создание периода отсутствия", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		startsAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		endsAt := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+		unavailability := &domain.Unavailability{
+			UserID:   "u2",
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+			Reason:   "vacation",
+		}
+
+		mock.ExpectQuery("INSERT INTO user_unavailability").
+			WithArgs(2, startsAt, endsAt, "vacation", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Now()))
+
+		err := repo.Create(context.Background(), unavailability)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, unavailability.ID)
+		assert.False(t, unavailability.CreatedAt.IsZero())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("границы периода сохраняются в UTC", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		moscow := time.FixedZone("MSK", 3*60*60)
+		startsAt := time.Date(2024, 7, 1, 3, 0, 0, 0, moscow)
+		endsAt := time.Date(2024, 7, 15, 3, 0, 0, 0, moscow)
+
+		mock.ExpectQuery("INSERT INTO user_unavailability").
+			WithArgs(2, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), "vacation", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Now()))
+
+		err := repo.Create(context.Background(), &domain.Unavailability{UserID: "u2", StartsAt: startsAt, EndsAt: endsAt, Reason: "vacation"})
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: невалидный ID пользователя", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		err := repo.Create(context.Background(), &domain.Unavailability{UserID: "invalid"})
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid user ID", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUnavailabilityRepository_GetByID - тест для метода GetByID()
+func TestUnavailabilityRepository_GetByID(t *testing.T) {
+	t.Run("успешное получение периода отсутствия", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		startsAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		endsAt := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+		reassignedAt := time.Date(2024, 7, 1, 0, 1, 0, 0, time.UTC)
+
+		mock.ExpectQuery("SELECT id, user_id, starts_at, ends_at, reason, reviews_reassigned_at, created_at, updated_at").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(unavailabilityColumns).
+				AddRow(7, 2, startsAt, endsAt, "vacation", reassignedAt, startsAt, nil))
+
+		unavailability, err := repo.GetByID(context.Background(), 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, unavailability.ID)
+		assert.Equal(t, "u2", unavailability.UserID)
+		assert.Equal(t, startsAt, unavailability.StartsAt)
+		assert.Equal(t, endsAt, unavailability.EndsAt)
+		require.NotNil(t, unavailability.ReviewsReassignedAt)
+		assert.Equal(t, reassignedAt, *unavailability.ReviewsReassignedAt)
+		assert.Nil(t, unavailability.UpdatedAt)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: период не найден", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		mock.ExpectQuery("SELECT id, user_id, starts_at, ends_at").
+			WithArgs(999).
+			WillReturnError(sql.ErrNoRows)
+
+		unavailability, err := repo.GetByID(context.Background(), 999)
+
+		require.Error(t, err)
+		assert.Nil(t, unavailability)
+		assert.Equal(t, "unavailability not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUnavailabilityRepository_GetByUserID - тест для метода GetByUserID()
+func TestUnavailabilityRepository_GetByUserID(t *testing.T) {
+	t.Run("успешное получение периодов пользователя", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		startsAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("SELECT id, user_id, starts_at, ends_at").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(unavailabilityColumns).
+				AddRow(1, 2, startsAt, startsAt.Add(24*time.Hour), "sick leave", nil, startsAt, nil).
+				AddRow(2, 2, startsAt.Add(30*24*time.Hour), startsAt.Add(44*24*time.Hour), "vacation", nil, startsAt, nil))
+
+		unavailabilities, err := repo.GetByUserID(context.Background(), "u2")
+
+		require.NoError(t, err)
+		require.Len(t, unavailabilities, 2)
+		assert.Equal(t, "sick leave", unavailabilities[0].Reason)
+		assert.Equal(t, "vacation", unavailabilities[1].Reason)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUnavailabilityRepository_Update - тест для метода Update()
+func TestUnavailabilityRepository_Update(t *testing.T) {
+	t.Run("успешное обновление сбрасывает отметку о переназначении", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		startsAt := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+		endsAt := time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectExec("UPDATE user_unavailability SET starts_at = \\$2, ends_at = \\$3, reason = \\$4, reviews_reassigned_at = NULL").
+			WithArgs(7, startsAt, endsAt, "vacation", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Update(context.Background(), &domain.Unavailability{
+			ID:       7,
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+			Reason:   "vacation",
+		})
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: период не найден", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		mock.ExpectExec("UPDATE user_unavailability").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Update(context.Background(), &domain.Unavailability{ID: 999})
+
+		require.Error(t, err)
+		assert.Equal(t, "unavailability not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUnavailabilityRepository_Delete - тест для метода Delete()
+func TestUnavailabilityRepository_Delete(t *testing.T) {
+	t.Run("успешное удаление", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		mock.ExpectExec("DELETE FROM user_unavailability").
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Delete(context.Background(), 7)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: период не найден", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		mock.ExpectExec("DELETE FROM user_unavailability").
+			WithArgs(999).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Delete(context.Background(), 999)
+
+		require.Error(t, err)
+		assert.Equal(t, "unavailability not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUnavailabilityRepository_GetStartedPending - тест для метода GetStartedPending()
+// Возвращаются только действующие периоды, ревью которых еще не переназначены
+func TestUnavailabilityRepository_GetStartedPending(t *testing.T) {
+	t.Run("успешное получение начавшихся периодов", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("WHERE reviews_reassigned_at IS NULL AND starts_at <= NOW\\(\\) AT TIME ZONE 'UTC' AND ends_at > NOW\\(\\) AT TIME ZONE 'UTC'").
+			WithArgs().
+			WillReturnRows(sqlmock.NewRows(unavailabilityColumns).
+				AddRow(7, 2, now.Add(-time.Hour), now.Add(24*time.Hour), "vacation", nil, now, nil))
+
+		unavailabilities, err := repo.GetStartedPending(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, unavailabilities, 1)
+		assert.Equal(t, "u2", unavailabilities[0].UserID)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUnavailabilityRepository_MarkReviewsReassigned - тест для метода MarkReviewsReassigned()
+func TestUnavailabilityRepository_MarkReviewsReassigned(t *testing.T) {
+	t.Run("успешная отметка", func(t *testing.T) {
+		repo, mock := setupUnavailabilityRepo(t)
+
+		mock.ExpectExec("UPDATE user_unavailability SET reviews_reassigned_at = NOW\\(\\) AT TIME ZONE 'UTC'").
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.MarkReviewsReassigned(context.Background(), 7)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
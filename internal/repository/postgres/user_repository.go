@@ -33,6 +33,14 @@ func intToStringID(id int) string {
 	return fmt.Sprintf("u%d", id)
 }
 
+func nullIntToPtr(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
+
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
 		INSERT INTO users (name, team_id, is_active, created_at)
@@ -151,7 +159,11 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	}
 
 	query := `
-		SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at
+		SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews, u.selection_weight,
+			EXISTS (
+				SELECT 1 FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AT TIME ZONE 'UTC' AND ua.ends_at > NOW() AT TIME ZONE 'UTC'
+			)
 		FROM users u
 		JOIN teams t ON u.team_id = t.id
 		WHERE u.id = $1
@@ -159,6 +171,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 
 	user := &domain.User{}
 	var updatedAt sql.NullTime
+	var maxOpenReviews sql.NullInt64
 	err = r.executor.QueryRowContext(ctx, query, dbID).Scan(
 		&dbID,
 		&user.Username,
@@ -167,6 +180,9 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 		&user.IsActive,
 		&user.CreatedAt,
 		&updatedAt,
+		&maxOpenReviews,
+		&user.SelectionWeight,
+		&user.Unavailable,
 	)
 
 	if updatedAt.Valid {
@@ -183,13 +199,18 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	}
 
 	user.ID = intToStringID(dbID)
+	user.MaxOpenReviews = nullIntToPtr(maxOpenReviews)
 
 	return user, nil
 }
 
 func (r *userRepository) GetActiveByTeamID(ctx context.Context, teamID int) ([]*domain.User, error) {
 	query := `
-		SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at
+		SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews, u.selection_weight,
+			EXISTS (
+				SELECT 1 FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AT TIME ZONE 'UTC' AND ua.ends_at > NOW() AT TIME ZONE 'UTC'
+			)
 		FROM users u
 		JOIN teams t ON u.team_id = t.id
 		WHERE u.team_id = $1 AND u.is_active = TRUE
@@ -207,6 +228,7 @@ func (r *userRepository) GetActiveByTeamID(ctx context.Context, teamID int) ([]*
 		user := &domain.User{}
 		var dbID int
 		var updatedAt sql.NullTime
+		var maxOpenReviews sql.NullInt64
 		err := rows.Scan(
 			&dbID,
 			&user.Username,
@@ -215,6 +237,9 @@ func (r *userRepository) GetActiveByTeamID(ctx context.Context, teamID int) ([]*
 			&user.IsActive,
 			&user.CreatedAt,
 			&updatedAt,
+			&maxOpenReviews,
+			&user.SelectionWeight,
+			&user.Unavailable,
 		)
 		if err != nil {
 			return nil, err
@@ -225,6 +250,7 @@ func (r *userRepository) GetActiveByTeamID(ctx context.Context, teamID int) ([]*
 			user.UpdatedAt = nil
 		}
 		user.ID = intToStringID(dbID)
+		user.MaxOpenReviews = nullIntToPtr(maxOpenReviews)
 		users = append(users, user)
 	}
 
@@ -233,7 +259,11 @@ func (r *userRepository) GetActiveByTeamID(ctx context.Context, teamID int) ([]*
 
 func (r *userRepository) GetByTeamID(ctx context.Context, teamID int) ([]*domain.User, error) {
 	query := `
-		SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at
+		SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews, u.selection_weight,
+			EXISTS (
+				SELECT 1 FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AT TIME ZONE 'UTC' AND ua.ends_at > NOW() AT TIME ZONE 'UTC'
+			)
 		FROM users u
 		JOIN teams t ON u.team_id = t.id
 		WHERE u.team_id = $1
@@ -251,6 +281,7 @@ func (r *userRepository) GetByTeamID(ctx context.Context, teamID int) ([]*domain
 		user := &domain.User{}
 		var dbID int
 		var updatedAt sql.NullTime
+		var maxOpenReviews sql.NullInt64
 		err := rows.Scan(
 			&dbID,
 			&user.Username,
@@ -259,6 +290,9 @@ func (r *userRepository) GetByTeamID(ctx context.Context, teamID int) ([]*domain
 			&user.IsActive,
 			&user.CreatedAt,
 			&updatedAt,
+			&maxOpenReviews,
+			&user.SelectionWeight,
+			&user.Unavailable,
 		)
 		if err != nil {
 			return nil, err
@@ -269,6 +303,7 @@ func (r *userRepository) GetByTeamID(ctx context.Context, teamID int) ([]*domain
 			user.UpdatedAt = nil
 		}
 		user.ID = intToStringID(dbID)
+		user.MaxOpenReviews = nullIntToPtr(maxOpenReviews)
 		users = append(users, user)
 	}
 
@@ -303,3 +338,140 @@ func (r *userRepository) SetIsActive(ctx context.Context, userID string, isActiv
 
 	return nil
 }
+
+func (r *userRepository) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	dbID, err := stringIDToInt(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	query := `
+		UPDATE users
+		SET max_open_reviews = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	var limit sql.NullInt64
+	if maxOpenReviews != nil {
+		limit = sql.NullInt64{Int64: int64(*maxOpenReviews), Valid: true}
+	}
+
+	result, err := r.executor.ExecContext(ctx, query, dbID, limit, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetSelectionWeight(ctx context.Context, userID string, weight float64) error {
+	dbID, err := stringIDToInt(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	query := `
+		UPDATE users
+		SET selection_weight = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.executor.ExecContext(ctx, query, dbID, weight, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetSkills(ctx context.Context, userID string, skills []string) error {
+	dbID, err := stringIDToInt(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	_, err = r.executor.ExecContext(ctx, "DELETE FROM user_skills WHERE user_id = $1", dbID)
+	if err != nil {
+		return err
+	}
+
+	for _, skill := range skills {
+		_, err := r.executor.ExecContext(ctx, "INSERT INTO user_skills (user_id, tag) VALUES ($1, $2)", dbID, skill)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetSkills(ctx context.Context, userID string) ([]string, error) {
+	dbID, err := stringIDToInt(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	rows, err := r.executor.QueryContext(ctx, "SELECT tag FROM user_skills WHERE user_id = $1 ORDER BY tag", dbID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make([]string, 0)
+	for rows.Next() {
+		var skill string
+		if err := rows.Scan(&skill); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+
+	return skills, rows.Err()
+}
+
+func (r *userRepository) GetSkillsByTeamID(ctx context.Context, teamID int) (map[string][]string, error) {
+	query := `
+		SELECT us.user_id, us.tag
+		FROM user_skills us
+		JOIN users u ON us.user_id = u.id
+		WHERE u.team_id = $1
+		ORDER BY us.user_id, us.tag
+	`
+
+	rows, err := r.executor.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make(map[string][]string)
+	for rows.Next() {
+		var userDBID int
+		var skill string
+		if err := rows.Scan(&userDBID, &skill); err != nil {
+			return nil, err
+		}
+		userID := intToStringID(userDBID)
+		skills[userID] = append(skills[userID], skill)
+	}
+
+	return skills, rows.Err()
+}
@@ -235,9 +235,9 @@ func TestUserRepository_GetByID(t *testing.T) {
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 		updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at"}).
-			AddRow(1, "john_doe", 1, "Team A", true, createdAt, updatedAt)
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}).
+			AddRow(1, "john_doe", 1, "Team A", true, createdAt, updatedAt, 5, 0.3, false)
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -251,6 +251,9 @@ func TestUserRepository_GetByID(t *testing.T) {
 		assert.Equal(t, "Team A", user.TeamName)
 		assert.True(t, user.IsActive)
 		assert.NotNil(t, user.UpdatedAt)
+		require.NotNil(t, user.MaxOpenReviews)
+		assert.Equal(t, 5, *user.MaxOpenReviews)
+		assert.Equal(t, 0.3, user.SelectionWeight)
 
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err)
@@ -261,9 +264,9 @@ func TestUserRepository_GetByID(t *testing.T) {
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at"}).
-			AddRow(1, "john_doe", 1, "Team A", true, createdAt, nil)
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}).
+			AddRow(1, "john_doe", 1, "Team A", true, createdAt, nil, nil, 1.0, false)
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -280,7 +283,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
 		repo, mock := setupUserRepo(t)
 
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(999).
 			WillReturnError(sql.ErrNoRows)
 
@@ -315,10 +318,10 @@ func TestUserRepository_GetActiveByTeamID(t *testing.T) {
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at"}).
-			AddRow(1, "user1", 1, "Team A", true, createdAt, nil).
-			AddRow(2, "user2", 1, "Team A", true, createdAt, nil)
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}).
+			AddRow(1, "user1", 1, "Team A", true, createdAt, nil, nil, 1.0, false).
+			AddRow(2, "user2", 1, "Team A", true, createdAt, nil, nil, 1.0, false)
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -338,8 +341,8 @@ func TestUserRepository_GetActiveByTeamID(t *testing.T) {
 	t.Run("успешное получение пустого списка", func(t *testing.T) {
 		repo, mock := setupUserRepo(t)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at"})
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"})
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -360,11 +363,11 @@ func TestUserRepository_GetByTeamID(t *testing.T) {
 
 		createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at"}).
-			AddRow(1, "user1", 1, "Team A", true, createdAt, nil).
-			AddRow(2, "user2", 1, "Team A", false, createdAt, nil).
-			AddRow(3, "user3", 1, "Team A", true, createdAt, nil)
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}).
+			AddRow(1, "user1", 1, "Team A", true, createdAt, nil, nil, 1.0, false).
+			AddRow(2, "user2", 1, "Team A", false, createdAt, nil, nil, 1.0, false).
+			AddRow(3, "user3", 1, "Team A", true, createdAt, nil, nil, 1.0, true)
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -376,6 +379,8 @@ func TestUserRepository_GetByTeamID(t *testing.T) {
 		assert.True(t, users[0].IsActive)
 		assert.False(t, users[1].IsActive)
 		assert.True(t, users[2].IsActive)
+		assert.False(t, users[0].Unavailable)
+		assert.True(t, users[2].Unavailable, "пользователь в отпуске помечается как недоступный")
 
 		err = mock.ExpectationsWereMet()
 		assert.NoError(t, err)
@@ -384,8 +389,8 @@ func TestUserRepository_GetByTeamID(t *testing.T) {
 	t.Run("успешное получение пустого списка", func(t *testing.T) {
 		repo, mock := setupUserRepo(t)
 
-		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at"})
-		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at").
+		rows := sqlmock.NewRows([]string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"})
+		mock.ExpectQuery("SELECT u.id, u.name, u.team_id, t.name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -444,3 +449,140 @@ func TestUserRepository_SetIsActive(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+// TestUserRepository_SetMaxOpenReviews - тест для метода SetMaxOpenReviews()
+func TestUserRepository_SetMaxOpenReviews(t *testing.T) {
+	t.Run("успешная установка ограничения", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectExec("UPDATE users").
+			WithArgs(1, sql.NullInt64{Int64: 3, Valid: true}, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		limit := 3
+		err := repo.SetMaxOpenReviews(context.Background(), "u1", &limit)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("успешное снятие ограничения", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectExec("UPDATE users").
+			WithArgs(1, sql.NullInt64{}, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetMaxOpenReviews(context.Background(), "u1", nil)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectExec("UPDATE users").
+			WithArgs(999, sql.NullInt64{}, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.SetMaxOpenReviews(context.Background(), "u999", nil)
+
+		require.Error(t, err)
+		assert.Equal(t, "user not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUserRepository_SetSelectionWeight - тест для метода SetSelectionWeight()
+func TestUserRepository_SetSelectionWeight(t *testing.T) {
+	t.Run("успешная установка веса", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectExec("UPDATE users").
+			WithArgs(1, 0.3, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetSelectionWeight(context.Background(), "u1", 0.3)
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectExec("UPDATE users").
+			WithArgs(999, 0.5, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.SetSelectionWeight(context.Background(), "u999", 0.5)
+
+		require.Error(t, err)
+		assert.Equal(t, "user not found", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUserRepository_SetSkills - тест для метода SetSkills()
+// Навыки пользователя полностью заменяются
+func TestUserRepository_SetSkills(t *testing.T) {
+	t.Run("успешная замена навыков", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectExec("DELETE FROM user_skills").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO user_skills").WithArgs(2, "go").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO user_skills").WithArgs(2, "sql").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetSkills(context.Background(), "u2", []string{"go", "sql"})
+
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("ошибка: невалидный ID пользователя", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		err := repo.SetSkills(context.Background(), "invalid", []string{"go"})
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid user ID", err.Error())
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+// TestUserRepository_GetSkillsByTeamID - тест для метода GetSkillsByTeamID()
+func TestUserRepository_GetSkillsByTeamID(t *testing.T) {
+	t.Run("успешное получение навыков участников команды", func(t *testing.T) {
+		repo, mock := setupUserRepo(t)
+
+		mock.ExpectQuery("SELECT us.user_id, us.tag").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "tag"}).
+				AddRow(1, "go").
+				AddRow(1, "sql").
+				AddRow(2, "frontend"))
+
+		skills, err := repo.GetSkillsByTeamID(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"u1": {"go", "sql"}, "u2": {"frontend"}}, skills)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
@@ -10,10 +10,21 @@ import (
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr *domain.PullRequest) error
 	GetByID(ctx context.Context, id string) (*domain.PullRequest, error)
-	UpdateStatus(ctx context.Context, id string, status domain.Status, mergedAt *time.Time) error
+	// GetByIDForUpdate возвращает PR, блокируя его строку до конца транзакции
+	GetByIDForUpdate(ctx context.Context, id string) (*domain.PullRequest, error)
+	UpdateStatus(ctx context.Context, id string, status domain.Status, changedAt *time.Time) error
 	AddReviewer(ctx context.Context, prID string, reviewerID string) error
 	RemoveReviewer(ctx context.Context, prID string, reviewerID string) error
 	GetReviewersByPRID(ctx context.Context, prID string) ([]string, error)
+	// List возвращает до filter.Limit PR, удовлетворяющих filter
+	List(ctx context.Context, filter domain.PullRequestFilter) ([]*domain.PullRequest, error)
 	GetPRsByReviewerID(ctx context.Context, reviewerID string) ([]*domain.PullRequestShort, error)
+	GetAwaitingReviewPRs(ctx context.Context, reviewerID string) ([]*domain.PullRequestShort, error)
 	ReplaceReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) error
+	GetOpenReviewCountsByTeamID(ctx context.Context, teamID int) (map[string]int, error)
+	GetRecentReviewCounts(ctx context.Context, authorID string, lastPRs int) (map[string]int, error)
+	CreateAssignment(ctx context.Context, assignment *domain.Assignment) error
+	GetAssignments(ctx context.Context, prID string) ([]*domain.Assignment, error)
+	CreateReview(ctx context.Context, review *domain.Review) error
+	CreateMergeOverride(ctx context.Context, override *domain.MergeOverride) error
 }
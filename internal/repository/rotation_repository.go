@@ -0,0 +1,8 @@
+package repository
+
+import "context"
+
+type RotationRepository interface {
+	AdvanceCursor(ctx context.Context, teamID int, next func(cursorID string) (string, error)) error
+	GetCursor(ctx context.Context, teamID int) (string, error)
+}
@@ -8,5 +8,6 @@ import (
 
 type StatsRepository interface {
 	GetReviewerStats(ctx context.Context) ([]*domain.ReviewerStat, error)
+	GetAuthorStats(ctx context.Context) ([]*domain.AuthorStat, error)
 	GetPRStatsByStatus(ctx context.Context) ([]*domain.PRStatusStat, error)
 }
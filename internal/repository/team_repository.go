@@ -9,4 +9,11 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, team *domain.Team) error
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
+	UpdateSettings(ctx context.Context, teamID int, minReviewers, maxReviewers int) error
+	GetFallbackTeams(ctx context.Context, teamID int) ([]*domain.Team, error)
+	SetFallbackTeams(ctx context.Context, teamID int, fallbackTeamIDs []int) error
+	GetMandatoryReviewers(ctx context.Context, teamID int) ([]string, error)
+	SetMandatoryReviewers(ctx context.Context, teamID int, userIDs []string) error
+	GetMergePolicy(ctx context.Context, teamID int) (domain.MergePolicy, error)
+	SetMergePolicy(ctx context.Context, teamID int, policy domain.MergePolicy) error
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+type UnavailabilityRepository interface {
+	Create(ctx context.Context, unavailability *domain.Unavailability) error
+	GetByID(ctx context.Context, id int) (*domain.Unavailability, error)
+	GetByUserID(ctx context.Context, userID string) ([]*domain.Unavailability, error)
+	Update(ctx context.Context, unavailability *domain.Unavailability) error
+	Delete(ctx context.Context, id int) error
+	// GetStartedPending возвращает периоды, действующие по часам БД, для которых ревью еще не переназначены
+	GetStartedPending(ctx context.Context) ([]*domain.Unavailability, error)
+	MarkReviewsReassigned(ctx context.Context, id int) error
+}
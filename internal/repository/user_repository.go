@@ -14,4 +14,12 @@ type UserRepository interface {
 	GetActiveByTeamID(ctx context.Context, teamID int) ([]*domain.User, error)
 	GetByTeamID(ctx context.Context, teamID int) ([]*domain.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error
+	// SetSelectionWeight задает вес пользователя при случайном выборе ревьюверов
+	SetSelectionWeight(ctx context.Context, userID string, weight float64) error
+	// SetSkills заменяет навыки пользователя на skills
+	SetSkills(ctx context.Context, userID string, skills []string) error
+	GetSkills(ctx context.Context, userID string) ([]string, error)
+	// GetSkillsByTeamID возвращает навыки участников команды (ID пользователя -> навыки)
+	GetSkillsByTeamID(ctx context.Context, teamID int) (map[string][]string, error)
 }
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+// newSelectionInputs создает входные данные выбора по запросу req к стратегии; candidates - кандидаты req.
+// Курсор ротации, нагрузку и историю ревью стратегия дописывает сама при выборе.
+func newSelectionInputs(req SelectionRequest, candidates []*domain.User) *domain.SelectionInputs {
+	inputs := &domain.SelectionInputs{
+		Pool:         make([]string, 0, len(req.TeamMembers)),
+		MaxReviewers: req.MaxReviewers,
+		Weights:      make(map[string]float64, len(candidates)),
+		Preferred:    req.PreferredUserIDs,
+	}
+	for _, member := range req.TeamMembers {
+		inputs.Pool = append(inputs.Pool, member.ID)
+	}
+	for _, candidate := range candidates {
+		inputs.Weights[candidate.ID] = candidate.EffectiveSelectionWeight()
+	}
+	return inputs
+}
+
+// replayAssignment повторяет выбор ревьюверов assignment с его seed по входным данным, сохраненным
+// в трассировке. Текущие данные БД не читаются, поэтому на результат не влияют изменения команды,
+// нагрузки, курсора ротации и истории ревью после назначения, в том числе внесенные самим назначением.
+// Второе значение false, если у записи нет входных данных (она сохранена до того, как они стали записываться).
+func replayAssignment(ctx context.Context, assignment *domain.Assignment) ([]string, bool, error) {
+	if assignment.Event == domain.AssignmentAddReviewer || assignment.Event == domain.AssignmentRemoveReviewer {
+		// Ручные изменения не содержат случайного выбора
+		return assignment.SelectedReviewers, true, nil
+	}
+
+	selectedReviewers := make([]string, 0, len(assignment.SelectedReviewers))
+	if assignment.Event == domain.AssignmentManualReassign && len(assignment.SelectedReviewers) > 0 {
+		// Новый ревьювер указан явно, случайно выбираются только добранные до min_reviewers
+		selectedReviewers = append(selectedReviewers, assignment.SelectedReviewers[0])
+	}
+
+	// Шаги выбора используют один источник случайности в порядке записи, как при назначении
+	rng := newSeededRand(assignment.Seed)
+	for _, step := range assignment.Steps {
+		if step.Source == domain.SourceMandatory {
+			// Обязательные ревьюверы назначаются все, без стратегии
+			selectedReviewers = append(selectedReviewers, step.Candidates...)
+			continue
+		}
+		if step.Inputs == nil {
+			return []string{}, false, nil
+		}
+
+		stepSelected, err := replayStep(ctx, rng, step)
+		if err != nil {
+			return nil, false, err
+		}
+		selectedReviewers = append(selectedReviewers, stepSelected...)
+	}
+
+	return selectedReviewers, true, nil
+}
+
+// replayStep повторяет выбор стратегии step.Strategy на входных данных step.Inputs
+func replayStep(ctx context.Context, rng *rand.Rand, step *domain.SelectionStep) ([]string, error) {
+	inputs := step.Inputs
+
+	var selector ReviewerSelector
+	switch step.Strategy {
+	case StrategyRandom:
+		selector = NewRandomSelector()
+	case StrategyRoundRobin:
+		selector = NewRoundRobinSelector(&recordedRotation{cursorID: inputs.CursorID})
+	case StrategyLeastLoaded:
+		loads := inputs.Loads
+		if loads == nil {
+			loads = map[string]int{}
+		}
+		selector = &leastLoadedSelector{loads: loads}
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy %q", step.Strategy)
+	}
+
+	// Участники пула, не ставшие кандидатами, исключаются так же, как при назначении
+	members := make([]*domain.User, 0, len(inputs.Pool))
+	excludeUserIDs := make([]string, 0, len(inputs.Pool))
+	for _, userID := range inputs.Pool {
+		members = append(members, &domain.User{ID: userID, IsActive: true, SelectionWeight: inputs.Weights[userID]})
+		if !slices.Contains(step.Candidates, userID) {
+			excludeUserIDs = append(excludeUserIDs, userID)
+		}
+	}
+
+	return selector.Select(ctx, SelectionRequest{
+		Team:             &domain.Team{Name: step.TeamName},
+		TeamMembers:      members,
+		ExcludeUserIDs:   excludeUserIDs,
+		MaxReviewers:     inputs.MaxReviewers,
+		Rand:             rng,
+		DryRun:           true,
+		RecentReviews:    inputs.RecentReviews,
+		PreferredUserIDs: inputs.Preferred,
+	})
+}
+
+// recordedRotation отдает курсор ротации, сохраненный при назначении, и никуда не записывает сдвиги
+type recordedRotation struct {
+	cursorID string
+}
+
+func (r *recordedRotation) AdvanceCursor(_ context.Context, _ int, next func(cursorID string) (string, error)) error {
+	_, err := next(r.cursorID)
+	return err
+}
+
+func (r *recordedRotation) GetCursor(_ context.Context, _ int) (string, error) {
+	return r.cursorID, nil
+}
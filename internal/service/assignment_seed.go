@@ -0,0 +1,61 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"strings"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+// AssignmentSeeder выводит seed выбора ревьюверов из ID PR, события и секрета сервера.
+// Без секрета seed можно было бы подобрать заранее, подбирая ID PR под нужных ревьюверов.
+type AssignmentSeeder struct {
+	secret []byte
+}
+
+// NewAssignmentSeeder создает AssignmentSeeder с секретом secret
+func NewAssignmentSeeder(secret string) *AssignmentSeeder {
+	return &AssignmentSeeder{secret: []byte(secret)}
+}
+
+// Seed возвращает HMAC-SHA256(secret, ID PR и ключ события), усеченный до int64.
+// Для nil-получателя используется пустой секрет.
+func (s *AssignmentSeeder) Seed(pullRequestID string, eventKey ...string) int64 {
+	var secret []byte
+	if s != nil {
+		secret = s.secret
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(pullRequestID))
+	for _, part := range eventKey {
+		mac.Write([]byte{0})
+		mac.Write([]byte(part))
+	}
+
+	return int64(binary.BigEndian.Uint64(mac.Sum(nil)[:8]))
+}
+
+// createSeedKey - ключ события создания PR
+func createSeedKey() []string {
+	return []string{string(domain.AssignmentCreate)}
+}
+
+// markReadySeedKey - ключ события перевода черновика в OPEN
+func markReadySeedKey() []string {
+	return []string{string(domain.AssignmentMarkReady)}
+}
+
+// reassignSeedKey - ключ события замены ревьювера; включает текущих ревьюверов,
+// чтобы повторные замены на одном PR получали разные seed
+func reassignSeedKey(oldReviewerID string, currentReviewers []string) []string {
+	return []string{string(domain.AssignmentReassign), oldReviewerID, strings.Join(currentReviewers, ",")}
+}
+
+// newSeededRand создает источник случайности с начальным значением seed
+func newSeededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignmentSeeder_Seed(t *testing.T) {
+	t.Run("seed детерминирован для PR и события", func(t *testing.T) {
+		seeder := NewAssignmentSeeder("secret")
+
+		assert.Equal(t, seeder.Seed("pr-1", createSeedKey()...), seeder.Seed("pr-1", createSeedKey()...))
+		assert.Equal(t, NewAssignmentSeeder("secret").Seed("pr-1", createSeedKey()...), seeder.Seed("pr-1", createSeedKey()...))
+	})
+
+	t.Run("seed зависит от PR, события и секрета", func(t *testing.T) {
+		seeder := NewAssignmentSeeder("secret")
+		seed := seeder.Seed("pr-1", createSeedKey()...)
+
+		assert.NotEqual(t, seed, seeder.Seed("pr-2", createSeedKey()...))
+		assert.NotEqual(t, seed, seeder.Seed("pr-1", reassignSeedKey("u2", []string{"u2", "u3"})...))
+		assert.NotEqual(t, seed, NewAssignmentSeeder("other").Seed("pr-1", createSeedKey()...))
+	})
+
+	t.Run("повторные замены на одном PR получают разные seed", func(t *testing.T) {
+		seeder := NewAssignmentSeeder("secret")
+
+		assert.NotEqual(t,
+			seeder.Seed("pr-1", reassignSeedKey("u2", []string{"u2", "u3"})...),
+			seeder.Seed("pr-1", reassignSeedKey("u2", []string{"u2", "u4"})...),
+		)
+	})
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+// selectionTrace накапливает шаги выбора ревьюверов одного назначения
+type selectionTrace struct {
+	authorID    string
+	coAuthorIDs []string
+	steps       []*domain.SelectionStep
+}
+
+// record добавляет шаг выбора из members. available - участники, не достигшие ограничения нагрузки;
+// excludeUserIDs - автор, соавторы и ревьюверы, уже назначенные на PR или выбранные на предыдущих шагах;
+// inputs - входные данные стратегии на шаге.
+func (t *selectionTrace) record(
+	source domain.SelectionSource,
+	team *domain.Team,
+	strategy string,
+	members []*domain.User,
+	available []*domain.User,
+	excludeUserIDs []string,
+	selected []string,
+	inputs *domain.SelectionInputs,
+) {
+	excluded := make(map[string]bool, len(excludeUserIDs))
+	for _, userID := range excludeUserIDs {
+		excluded[userID] = true
+	}
+	coAuthors := make(map[string]bool, len(t.coAuthorIDs))
+	for _, userID := range t.coAuthorIDs {
+		coAuthors[userID] = true
+	}
+	notSaturated := make(map[string]bool, len(available))
+	for _, user := range available {
+		notSaturated[user.ID] = true
+	}
+
+	step := &domain.SelectionStep{
+		Source:     source,
+		TeamName:   team.Name,
+		Strategy:   strategy,
+		Candidates: []string{},
+		Excluded:   []*domain.ExcludedCandidate{},
+		Selected:   selected,
+		Inputs:     inputs,
+	}
+	for _, member := range members {
+		reason := exclusionReason(member, t.authorID, coAuthors, excluded, notSaturated)
+		if reason == "" {
+			step.Candidates = append(step.Candidates, member.ID)
+			continue
+		}
+		step.Excluded = append(step.Excluded, &domain.ExcludedCandidate{UserID: member.ID, Reason: reason})
+	}
+
+	t.steps = append(t.steps, step)
+}
+
+// exclusionReason возвращает причину, по которой member не кандидат, или пустую строку
+func exclusionReason(member *domain.User, authorID string, coAuthors, excluded, notSaturated map[string]bool) domain.ExclusionReason {
+	switch {
+	case member.ID == authorID:
+		return domain.ExclusionAuthor
+	case coAuthors[member.ID]:
+		return domain.ExclusionCoAuthor
+	case excluded[member.ID]:
+		return domain.ExclusionAlreadyAssigned
+	case !member.IsActive:
+		return domain.ExclusionInactive
+	case member.Unavailable:
+		return domain.ExclusionUnavailable
+	case !notSaturated[member.ID]:
+		return domain.ExclusionAtCapacity
+	default:
+		return ""
+	}
+}
+
+// stepsOf возвращает записанные шаги; для сервиса без трассировки - пустой список
+func (t *selectionTrace) stepsOf() []*domain.SelectionStep {
+	if t == nil {
+		return []*domain.SelectionStep{}
+	}
+	return t.steps
+}
+
+// authorOf возвращает автора PR, для которого ведется трассировка, или пустую строку
+func (t *selectionTrace) authorOf() string {
+	if t == nil {
+		return ""
+	}
+	return t.authorID
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+type CodeOwnersService interface {
+	SetCodeOwners(ctx context.Context, repository, content string) (*domain.CodeOwners, error)
+	GetCodeOwners(ctx context.Context, repository string) (*domain.CodeOwners, error)
+}
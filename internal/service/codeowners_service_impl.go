@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/codeowners"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository"
+)
+
+type codeOwnersService struct {
+	codeOwnersRepo repository.CodeOwnersRepository
+}
+
+// NewCodeOwnersService создает сервис файлов CODEOWNERS репозиториев
+func NewCodeOwnersService(codeOwnersRepo repository.CodeOwnersRepository) CodeOwnersService {
+	return &codeOwnersService{codeOwnersRepo: codeOwnersRepo}
+}
+
+// SetCodeOwners проверяет и сохраняет файл CODEOWNERS репозитория, заменяя ранее загруженный
+func (s *codeOwnersService) SetCodeOwners(ctx context.Context, repository, content string) (*domain.CodeOwners, error) {
+	if repository == "" {
+		return nil, domain.NewBadRequestError("repository is required")
+	}
+
+	ruleset, err := codeowners.Parse(content)
+	if err != nil {
+		return nil, domain.NewBadRequestError("invalid CODEOWNERS: " + err.Error())
+	}
+
+	codeOwners := &domain.CodeOwners{
+		Repository: repository,
+		Content:    content,
+	}
+
+	err = s.codeOwnersRepo.Upsert(ctx, codeOwners)
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwners.Rules = domainCodeOwnersRules(ruleset)
+	return codeOwners, nil
+}
+
+func (s *codeOwnersService) GetCodeOwners(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	codeOwners, err := s.codeOwnersRepo.GetByRepository(ctx, repository)
+	if err != nil {
+		if err.Error() == "codeowners not found" {
+			return nil, domain.NewNotFoundError("codeowners for repository " + repository)
+		}
+		return nil, err
+	}
+
+	ruleset, err := codeowners.Parse(codeOwners.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwners.Rules = domainCodeOwnersRules(ruleset)
+	return codeOwners, nil
+}
+
+func domainCodeOwnersRules(ruleset *codeowners.Ruleset) []domain.CodeOwnersRule {
+	rules := make([]domain.CodeOwnersRule, 0, len(ruleset.Rules()))
+	for _, rule := range ruleset.Rules() {
+		owners := make([]string, 0, len(rule.Owners))
+		for _, owner := range rule.Owners {
+			owners = append(owners, owner.String())
+		}
+		rules = append(rules, domain.CodeOwnersRule{Pattern: rule.Pattern, Owners: owners})
+	}
+	return rules
+}
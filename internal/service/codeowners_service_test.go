@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOwnersService_SetCodeOwners(t *testing.T) {
+	t.Run("успешная загрузка файла", func(t *testing.T) {
+		mockCodeOwnersRepo := new(mocks.MockCodeOwnersRepository)
+
+		service := NewCodeOwnersService(mockCodeOwnersRepo)
+
+		content := "*.go @u1 @org/backend\n/docs/ @u2\n"
+		mockCodeOwnersRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(c *domain.CodeOwners) bool {
+			return c.Repository == "avito/pr-reviewer" && c.Content == content
+		})).Return(nil).Once()
+
+		result, err := service.SetCodeOwners(context.Background(), "avito/pr-reviewer", content)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.CodeOwnersRule{
+			{Pattern: "*.go", Owners: []string{"@u1", "@team/backend"}},
+			{Pattern: "/docs/", Owners: []string{"@u2"}},
+		}, result.Rules)
+		mockCodeOwnersRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: некорректный файл не сохраняется", func(t *testing.T) {
+		mockCodeOwnersRepo := new(mocks.MockCodeOwnersRepository)
+
+		service := NewCodeOwnersService(mockCodeOwnersRepo)
+
+		result, err := service.SetCodeOwners(context.Background(), "avito/pr-reviewer", "*.go u1")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockCodeOwnersRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCodeOwnersService_GetCodeOwners(t *testing.T) {
+	t.Run("ошибка: файл не загружен", func(t *testing.T) {
+		mockCodeOwnersRepo := new(mocks.MockCodeOwnersRepository)
+
+		service := NewCodeOwnersService(mockCodeOwnersRepo)
+
+		mockCodeOwnersRepo.On("GetByRepository", mock.Anything, "unknown").Return(nil, errors.New("codeowners not found")).Once()
+
+		result, err := service.GetCodeOwners(context.Background(), "unknown")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockCodeOwnersRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+// cursorTimeLayout - формат времени создания PR в курсоре. Время создания хранится в БД без часового пояса,
+// поэтому в курсор попадают его дата и время как есть, без пересчета между поясами.
+const cursorTimeLayout = "2006-01-02T15:04:05.999999999"
+
+// encodeCursor кодирует позицию PR в списке в непрозрачную для клиента строку
+func encodeCursor(pr *domain.PullRequest) string {
+	value := pr.CreatedAt.Format(cursorTimeLayout) + "|" + pr.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeCursor разбирает курсор, полученный из encodeCursor
+func decodeCursor(cursor string) (*domain.PullRequestCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.NewBadRequestError("invalid cursor")
+	}
+
+	createdAtStr, prID, found := strings.Cut(string(value), "|")
+	if !found || prID == "" {
+		return nil, domain.NewBadRequestError("invalid cursor")
+	}
+	createdAt, err := time.Parse(cursorTimeLayout, createdAtStr)
+	if err != nil {
+		return nil, domain.NewBadRequestError("invalid cursor")
+	}
+
+	return &domain.PullRequestCursor{CreatedAt: createdAt, PullRequestID: prID}, nil
+}
@@ -2,12 +2,71 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
 )
 
+// CreatePRInput - параметры создания PR
+type CreatePRInput struct {
+	PullRequestID string
+	Title         string
+	AuthorID      string
+	// CoAuthorIDs - необязательные соавторы; как и автор, не назначаются ревьюверами
+	CoAuthorIDs []string
+	// Repository и ChangedFiles необязательны: если заданы, ревьюверы сначала выбираются
+	// из владельцев измененных путей по CODEOWNERS репозитория
+	Repository   string
+	ChangedFiles []string
+	// Tags - навыки, требуемые для ревью; ревьюверы с этими навыками выбираются в первую очередь
+	Tags []string
+	// Draft создает черновик без ревьюверов; Repository и ChangedFiles для него передаются в MarkReady
+	Draft bool
+}
+
+// SimulationInput - параметры симуляции выбора ревьюверов.
+// Гипотетический автор задается либо существующим пользователем AuthorID, либо командой TeamName.
+type SimulationInput struct {
+	AuthorID     string
+	TeamName     string
+	Iterations   int
+	Repository   string
+	ChangedFiles []string
+	Tags         []string
+}
+
+// ListPRsInput - параметры списка PR. Пустые фильтры выборку не ограничивают;
+// Cursor - NextCursor предыдущей страницы.
+type ListPRsInput struct {
+	Status      domain.Status
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Order - "desc" (по умолчанию, сначала новые) или "asc"
+	Order  string
+	Cursor string
+	Limit  int
+}
+
 type PullRequestService interface {
-	CreatePR(ctx context.Context, prID, title, authorID string) (*domain.PullRequest, error)
-	MergePR(ctx context.Context, prID string) (*domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error)
+	CreatePR(ctx context.Context, input CreatePRInput) (*domain.PullRequest, error)
+	GetPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ListPRs(ctx context.Context, input ListPRsInput) (*domain.PullRequestPage, error)
+	// MergePR переводит PR в MERGED; adminOverride разрешает merge в обход политики команды с записью в журнал
+	MergePR(ctx context.Context, prID string, adminOverride bool, overrideReason string) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, prID, repository string, changedFiles []string) (*domain.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	// ReassignReviewer заменяет ревьювера oldReviewerID; обязательного ревьювера команды можно заменить только с force = true
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID string, force bool) (*domain.PullRequest, string, error)
+	// ReassignReviewerTo заменяет ревьювера oldReviewerID на явно выбранного newReviewerID
+	ReassignReviewerTo(ctx context.Context, prID, oldReviewerID, newReviewerID string, force bool) (*domain.PullRequest, error)
+	AddReviewer(ctx context.Context, prID, reviewerID string) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, reviewerID string, force bool) (*domain.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, state domain.ReviewState) (*domain.PullRequest, error)
+	ExplainAssignment(ctx context.Context, prID string) ([]*domain.AssignmentReplay, error)
+	GetAssignments(ctx context.Context, prID string) ([]*domain.Assignment, error)
+	SimulateSelection(ctx context.Context, input SimulationInput) (*domain.SelectionSimulation, error)
 }
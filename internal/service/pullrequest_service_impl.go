@@ -2,33 +2,137 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/bagdasarian/avito-pr-reviewer/internal/codeowners"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/repository"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository/postgres"
 )
 
 type pullRequestService struct {
+	db              *sql.DB
 	pullRequestRepo repository.PullRequestRepository
 	userRepo        repository.UserRepository
 	teamRepo        repository.TeamRepository
+	codeOwnersRepo  repository.CodeOwnersRepository
+	selector        ReviewerSelector
+	seeder          *AssignmentSeeder
+
+	// rng передается стратегии при каждом выборе, trace записывает шаги выбора; задаются через withDraw
+	rng   *rand.Rand
+	trace *selectionTrace
 }
 
-// NewPullRequestService создает новый экземпляр PullRequestService
+// NewPullRequestService создает новый экземпляр PullRequestService.
+// db используется для транзакций, в которых изменения PR записываются вместе с историей выбора ревьюверов.
+// seeder выводит seed каждого выбора ревьюверов, чтобы выбор можно было воспроизвести.
 func NewPullRequestService(
+	db *sql.DB,
 	pullRequestRepo repository.PullRequestRepository,
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
+	codeOwnersRepo repository.CodeOwnersRepository,
+	selector ReviewerSelector,
+	seeder *AssignmentSeeder,
 ) PullRequestService {
 	return &pullRequestService{
+		db:              db,
 		pullRequestRepo: pullRequestRepo,
 		userRepo:        userRepo,
 		teamRepo:        teamRepo,
+		codeOwnersRepo:  codeOwnersRepo,
+		selector:        selector,
+		seeder:          seeder,
+	}
+}
+
+// newPullRequestServiceWithTx создает pullRequestService, все репозитории и стратегия выбора которого работают в транзакции tx
+func newPullRequestServiceWithTx(tx *sql.Tx, selector ReviewerSelector, seeder *AssignmentSeeder) *pullRequestService {
+	return &pullRequestService{
+		pullRequestRepo: postgres.NewPullRequestRepositoryWithTx(tx),
+		userRepo:        postgres.NewUserRepositoryWithTx(tx),
+		teamRepo:        postgres.NewTeamRepositoryWithTx(tx),
+		codeOwnersRepo:  postgres.NewCodeOwnersRepositoryWithTx(tx),
+		selector:        selectorWithTx(selector, tx),
+		seeder:          seeder,
+	}
+}
+
+// inTx выполняет fn с копией сервиса, репозитории и стратегия выбора которой работают в одной транзакции,
+// и фиксирует транзакцию, если fn завершилась без ошибки. Сервис без db уже работает в транзакции
+// вызывающего кода (см. newPullRequestServiceWithTx), и fn выполняется в ней.
+func (s *pullRequestService) inTx(ctx context.Context, fn func(txService *pullRequestService) error) error {
+	if s.db == nil {
+		return fn(s)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txService := newPullRequestServiceWithTx(tx, s.selector, s.seeder)
+	txService.rng, txService.trace = s.rng, s.trace
+	if err := fn(txService); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// withDraw возвращает копию сервиса, выбирающую ревьюверов с источником случайности rng
+// и записывающую шаги выбора для PR автора authorID и соавторов coAuthorIDs
+func (s *pullRequestService) withDraw(rng *rand.Rand, authorID string, coAuthorIDs ...string) *pullRequestService {
+	draw := *s
+	draw.rng = rng
+	draw.trace = &selectionTrace{authorID: authorID, coAuthorIDs: coAuthorIDs}
+	return &draw
+}
+
+// traceStep записывает шаг выбора в трассировку, если она ведется
+func (s *pullRequestService) traceStep(
+	source domain.SelectionSource,
+	team *domain.Team,
+	members []*domain.User,
+	available []*domain.User,
+	excludeUserIDs []string,
+	selected []string,
+	inputs *domain.SelectionInputs,
+) {
+	if s.trace == nil {
+		return
 	}
+	s.trace.record(source, team, s.selector.Strategy(team), members, available, excludeUserIDs, selected, inputs)
 }
 
-// CreatePR создает PR и автоматически назначает до 2 активных ревьюверов из команды автора
-func (s *pullRequestService) CreatePR(ctx context.Context, prID, title, authorID string) (*domain.PullRequest, error) {
+// CreatePR создает PR и автоматически назначает до team.MaxReviewers активных ревьюверов.
+// Автор и соавторы ревьюверами не назначаются.
+// Обязательные ревьюверы команды автора назначаются всегда и занимают места первыми. Если переданы репозиторий и измененные файлы, ревьюверы сначала выбираются из владельцев
+// этих путей по CODEOWNERS, оставшиеся места заполняются из команды автора с помощью стратегии выбора,
+// настроенной для этой команды. Участники, достигшие ограничения на количество OPEN PR на ревью,
+// не выбираются. Если не набирается team.MinReviewers кандидатов, недостающие берутся из резервных команд.
+// Выбор использует seed, выведенный из ID PR, и сохраняется вместе с ним.
+func (s *pullRequestService) CreatePR(ctx context.Context, input CreatePRInput) (*domain.PullRequest, error) {
+	prID, authorID := input.PullRequestID, input.AuthorID
+
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Draft && (input.Repository != "" || len(input.ChangedFiles) > 0) {
+		return nil, domain.NewBadRequestError("repository and changed_files of a draft PR are passed to markReady")
+	}
+
 	existingPR, err := s.pullRequestRepo.GetByID(ctx, prID)
 	if err == nil && existingPR != nil {
 		return nil, domain.ErrPRExists
@@ -45,6 +149,15 @@ func (s *pullRequestService) CreatePR(ctx context.Context, prID, title, authorID
 		return nil, err
 	}
 
+	input.CoAuthorIDs, err = s.validateCoAuthors(ctx, authorID, input.CoAuthorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Draft {
+		return s.createDraft(ctx, input, tags)
+	}
+
 	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
 	if err != nil {
 		if err.Error() == "team not found" {
@@ -58,19 +171,41 @@ func (s *pullRequestService) CreatePR(ctx context.Context, prID, title, authorID
 		return nil, err
 	}
 
-	selectedReviewers := SelectReviewers(teamMembers, authorID, 2)
+	seed := s.seeder.Seed(prID, createSeedKey()...)
+	draw := s.withDraw(newSeededRand(seed), authorID, input.CoAuthorIDs...)
+	selectedReviewers, err := draw.selectForCreate(ctx, input, team, teamMembers, tags)
+	if err != nil {
+		return nil, err
+	}
 
 	pr := &domain.PullRequest{
 		ID:                prID,
-		Title:             title,
+		Title:             input.Title,
 		AuthorID:          authorID,
+		CoAuthorIDs:       input.CoAuthorIDs,
 		Status:            domain.StatusOpen,
 		AssignedReviewers: selectedReviewers,
+		Tags:              tags,
 		CreatedAt:         time.Now(),
 		MergedAt:          nil,
 	}
 
-	err = s.pullRequestRepo.Create(ctx, pr)
+	err = s.inTx(ctx, func(txService *pullRequestService) error {
+		err := txService.pullRequestRepo.Create(ctx, pr)
+		if err != nil {
+			return err
+		}
+
+		return txService.pullRequestRepo.CreateAssignment(ctx, &domain.Assignment{
+			PullRequestID:     prID,
+			Event:             domain.AssignmentCreate,
+			Seed:              seed,
+			Repository:        input.Repository,
+			ChangedFiles:      input.ChangedFiles,
+			SelectedReviewers: selectedReviewers,
+			Steps:             draw.trace.stepsOf(),
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -87,9 +222,344 @@ func (s *pullRequestService) CreatePR(ctx context.Context, prID, title, authorID
 	return createdPR, nil
 }
 
-// MergePR помечает PR как MERGED (идемпотентная операция)
-func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+// GetPR возвращает PR с ревьюверами и их решениями
+func (s *pullRequestService) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	pr, err := s.pullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// ListPRs возвращает страницу PR, удовлетворяющих фильтрам input, упорядоченных по времени создания.
+// Страница содержит до input.Limit PR (по умолчанию defaultListLimit); NextCursor задан, если есть следующая.
+func (s *pullRequestService) ListPRs(ctx context.Context, input ListPRsInput) (*domain.PullRequestPage, error) {
+	if input.Status != "" && !input.Status.IsValid() {
+		return nil, domain.NewBadRequestError("status must be one of DRAFT, OPEN, MERGED, CLOSED")
+	}
+	if input.Order != "" && input.Order != "asc" && input.Order != "desc" {
+		return nil, domain.NewBadRequestError("order must be asc or desc")
+	}
+	if input.CreatedFrom != nil && input.CreatedTo != nil && !input.CreatedFrom.Before(*input.CreatedTo) {
+		return nil, domain.NewBadRequestError("created_from must be before created_to")
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit < 0 || limit > maxListLimit {
+		return nil, domain.NewBadRequestError("limit must be in range [1, 100]")
+	}
+
+	filter := domain.PullRequestFilter{
+		Status:      input.Status,
+		AuthorID:    input.AuthorID,
+		ReviewerID:  input.ReviewerID,
+		TeamName:    input.TeamName,
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		Ascending:   input.Order == "asc",
+		// Лишний PR показывает, что есть следующая страница
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		after, err := decodeCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	prs, err := s.pullRequestRepo.List(ctx, filter)
+	if err != nil {
+		switch err.Error() {
+		case "invalid author ID":
+			return nil, domain.NewBadRequestError("invalid author_id " + input.AuthorID)
+		case "invalid reviewer ID":
+			return nil, domain.NewBadRequestError("invalid reviewer_id " + input.ReviewerID)
+		case "invalid pull request ID":
+			return nil, domain.NewBadRequestError("invalid cursor")
+		}
+		return nil, err
+	}
+
+	page := &domain.PullRequestPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		page.NextCursor = encodeCursor(prs[limit-1])
+	}
+
+	return page, nil
+}
+
+// createDraft сохраняет черновик PR без ревьюверов; они назначаются при MarkReady
+func (s *pullRequestService) createDraft(ctx context.Context, input CreatePRInput, tags []string) (*domain.PullRequest, error) {
+	pr := &domain.PullRequest{
+		ID:                input.PullRequestID,
+		Title:             input.Title,
+		AuthorID:          input.AuthorID,
+		CoAuthorIDs:       input.CoAuthorIDs,
+		Status:            domain.StatusDraft,
+		AssignedReviewers: []string{},
+		Tags:              tags,
+		CreatedAt:         time.Now(),
+	}
+
+	err := s.inTx(ctx, func(txService *pullRequestService) error {
+		return txService.pullRequestRepo.Create(ctx, pr)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetByID(ctx, input.PullRequestID)
+}
+
+// MarkReady переводит черновик в OPEN и назначает ревьюверов так же, как CreatePR.
+// repository и changedFiles необязательны и включают выбор по CODEOWNERS.
+// Выбор записывается в историю событием MARK_READY. Выбор, назначение ревьюверов и смена статуса выполняются
+// в одной транзакции под блокировкой строки PR, поэтому параллельный вызов для того же черновика
+// дождется ее завершения и получит ошибку перехода статуса.
+func (s *pullRequestService) MarkReady(ctx context.Context, prID, repository string, changedFiles []string) (*domain.PullRequest, error) {
+	var readyPR *domain.PullRequest
+	err := s.inTx(ctx, func(txService *pullRequestService) error {
+		var err error
+		readyPR, err = txService.markReady(ctx, prID, repository, changedFiles)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return readyPR, nil
+}
+
+// markReady выполняет MarkReady в транзакции сервиса
+func (s *pullRequestService) markReady(ctx context.Context, prID, repository string, changedFiles []string) (*domain.PullRequest, error) {
+	pr, err := s.pullRequestRepo.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	status, err := nextStatus(pr, actionMarkReady)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.authorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMembers, err := s.userRepo.GetByTeamID(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := CreatePRInput{
+		PullRequestID: prID,
+		Title:         pr.Title,
+		AuthorID:      pr.AuthorID,
+		CoAuthorIDs:   pr.CoAuthorIDs,
+		Repository:    repository,
+		ChangedFiles:  changedFiles,
+		Tags:          pr.Tags,
+	}
+
+	seed := s.seeder.Seed(prID, markReadySeedKey()...)
+	draw := s.withDraw(newSeededRand(seed), pr.AuthorID, pr.CoAuthorIDs...)
+	selectedReviewers, err := draw.selectForCreate(ctx, input, team, teamMembers, pr.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reviewerID := range selectedReviewers {
+		err = s.pullRequestRepo.AddReviewer(ctx, prID, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.pullRequestRepo.UpdateStatus(ctx, prID, status, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pullRequestRepo.CreateAssignment(ctx, &domain.Assignment{
+		PullRequestID:     prID,
+		Event:             domain.AssignmentMarkReady,
+		Seed:              seed,
+		Repository:        repository,
+		ChangedFiles:      changedFiles,
+		SelectedReviewers: selectedReviewers,
+		Steps:             draw.trace.stepsOf(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetByID(ctx, prID)
+}
+
+// ClosePR закрывает черновик или OPEN PR без merge. Ревьюверы и их решения сохраняются.
+func (s *pullRequestService) ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.changeStatus(ctx, prID, actionClose)
+}
+
+// ReopenPR открывает закрытый PR заново с прежними ревьюверами; PR без ревьюверов возвращается в DRAFT
+func (s *pullRequestService) ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.changeStatus(ctx, prID, actionReopen)
+}
+
+// changeStatus переводит PR в статус, следующий после действия action, без изменения ревьюверов.
+// Проверка перехода и смена статуса выполняются в одной транзакции под блокировкой строки PR,
+// поэтому параллельный merge не может быть перезаписан закрытием.
+func (s *pullRequestService) changeStatus(ctx context.Context, prID string, action statusAction) (*domain.PullRequest, error) {
+	var changedPR *domain.PullRequest
+	err := s.inTx(ctx, func(txService *pullRequestService) error {
+		var err error
+		changedPR, err = txService.applyStatusAction(ctx, prID, action)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changedPR, nil
+}
+
+// applyStatusAction выполняет changeStatus в транзакции сервиса
+func (s *pullRequestService) applyStatusAction(ctx context.Context, prID string, action statusAction) (*domain.PullRequest, error) {
+	pr, err := s.pullRequestRepo.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	status, err := nextStatus(pr, action)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pullRequestRepo.UpdateStatus(ctx, prID, status, nil)
+	if err != nil {
+		if err.Error() == "pull request not found" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetByID(ctx, prID)
+}
+
+// validateCoAuthors проверяет, что соавторы существуют, не повторяются и не совпадают с автором
+func (s *pullRequestService) validateCoAuthors(ctx context.Context, authorID string, coAuthorIDs []string) ([]string, error) {
+	validated := make([]string, 0, len(coAuthorIDs))
+	for _, coAuthorID := range coAuthorIDs {
+		if coAuthorID == authorID {
+			return nil, domain.NewBadRequestError("author cannot be a co-author")
+		}
+		if slices.Contains(validated, coAuthorID) {
+			return nil, domain.NewBadRequestError("duplicate co-author " + coAuthorID)
+		}
+
+		_, err := s.userRepo.GetByID(ctx, coAuthorID)
+		if err != nil {
+			if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+				return nil, domain.NewNotFoundError("user with id " + coAuthorID)
+			}
+			return nil, err
+		}
+		validated = append(validated, coAuthorID)
+	}
+
+	return validated, nil
+}
+
+// selectForCreate выбирает ревьюверов нового PR: сначала обязательных ревьюверов команды автора,
+// затем владельцев измененных путей по CODEOWNERS, затем участников команды автора и ее резервных команд
+func (s *pullRequestService) selectForCreate(
+	ctx context.Context,
+	input CreatePRInput,
+	team *domain.Team,
+	teamMembers []*domain.User,
+	tags []string,
+) ([]string, error) {
+	authorIDs := append([]string{input.AuthorID}, input.CoAuthorIDs...)
+	mandatoryReviewers, err := s.selectMandatory(ctx, team, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerReviewers, ownersSaturated, err := s.selectCodeOwners(ctx, input, team, mandatoryReviewers, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedReviewers := append(mandatoryReviewers, ownerReviewers...)
+	excludeUserIDs := append(authorIDs, selectedReviewers...)
+	teamReviewers, saturated, err := s.selectWithFallback(
+		ctx,
+		team,
+		teamMembers,
+		excludeUserIDs,
+		tags,
+		team.MaxReviewers-len(selectedReviewers),
+		team.MinReviewers-len(selectedReviewers),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedReviewers = append(selectedReviewers, teamReviewers...)
+	if len(selectedReviewers) == 0 && saturated+ownersSaturated > 0 {
+		return nil, domain.NewNoCandidateError("all candidates are at review capacity")
+	}
+
+	return selectedReviewers, nil
+}
+
+// MergePR помечает PR как MERGED (идемпотентная операция).
+// PR должен удовлетворять политике merge команды автора, иначе возвращается NOT_APPROVED.
+// С adminOverride = true политика не проверяется, а ее нарушение записывается в журнал с причиной overrideReason.
+// Проверка политики, запись в журнал и смена статуса выполняются в одной транзакции под блокировкой строки PR,
+// поэтому решения ревьюверов и статус не могут измениться между проверкой и merge.
+func (s *pullRequestService) MergePR(ctx context.Context, prID string, adminOverride bool, overrideReason string) (*domain.PullRequest, error) {
+	if adminOverride && strings.TrimSpace(overrideReason) == "" {
+		return nil, domain.NewBadRequestError("override_reason is required for admin override")
+	}
+
+	var mergedPR *domain.PullRequest
+	err := s.inTx(ctx, func(txService *pullRequestService) error {
+		var err error
+		mergedPR, err = txService.merge(ctx, prID, adminOverride, overrideReason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergedPR, nil
+}
+
+// merge выполняет MergePR в транзакции сервиса
+func (s *pullRequestService) merge(ctx context.Context, prID string, adminOverride bool, overrideReason string) (*domain.PullRequest, error) {
+	pr, err := s.pullRequestRepo.GetByIDForUpdate(ctx, prID)
 	if err != nil {
 		if err.Error() == "pull request not found" {
 			return nil, domain.NewNotFoundError("pull request with id " + prID)
@@ -101,6 +571,38 @@ func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*domain.
 		return pr, nil
 	}
 
+	if _, err := nextStatus(pr, actionMerge); err != nil {
+		return nil, err
+	}
+
+	team, err := s.authorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.teamRepo.GetMergePolicy(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, violation := mergePolicyViolation(policy, pr)
+	if violation != "" {
+		if !adminOverride {
+			return nil, domain.NewNotApprovedError(violation)
+		}
+
+		err = s.pullRequestRepo.CreateMergeOverride(ctx, &domain.MergeOverride{
+			PullRequestID:     prID,
+			Reason:            overrideReason,
+			Violation:         violation,
+			Approvals:         approvals,
+			RequiredApprovals: policy.RequiredApprovals,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	now := time.Now()
 	err = s.pullRequestRepo.UpdateStatus(ctx, prID, domain.StatusMerged, &now)
 	if err != nil {
@@ -121,8 +623,53 @@ func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*domain.
 	return mergedPR, nil
 }
 
-// ReassignReviewer переназначает конкретного ревьювера на другого из его команды
-func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+// mergePolicyViolation возвращает количество одобрений назначенных ревьюверов PR и описание
+// невыполненного условия policy; пустое описание означает, что PR можно переводить в MERGED
+func mergePolicyViolation(policy domain.MergePolicy, pr *domain.PullRequest) (int, string) {
+	approvals := 0
+	var changesRequested []string
+	for _, reviewerID := range pr.AssignedReviewers {
+		switch pr.ReviewStates[reviewerID] {
+		case domain.ReviewApproved:
+			approvals++
+		case domain.ReviewChangesRequested:
+			changesRequested = append(changesRequested, reviewerID)
+		}
+	}
+
+	if policy.BlockOnChangesRequested && len(changesRequested) > 0 {
+		return approvals, "changes requested by " + strings.Join(changesRequested, ", ")
+	}
+	if approvals < policy.RequiredApprovals {
+		return approvals, fmt.Sprintf("%d of %d required approvals", approvals, policy.RequiredApprovals)
+	}
+
+	return approvals, ""
+}
+
+// ReassignReviewer переназначает конкретного ревьювера на другого из его команды,
+// а если в ней нет подходящего кандидата - из резервных команд.
+// Если после замены на PR меньше team.MinReviewers ревьюверов, недостающие добавляются тем же способом.
+// Обязательного ревьювера команды автора можно заменить только с force = true.
+func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string, force bool) (*domain.PullRequest, string, error) {
+	return s.reassign(ctx, prID, oldReviewerID, "", force)
+}
+
+// ReassignReviewerTo заменяет ревьювера oldReviewerID на newReviewerID. Новый ревьювер должен быть активен,
+// не отсутствовать, не быть автором или уже назначенным ревьювером и состоять в команде заменяемого ревьювера
+// или в одной из ее резервных команд. Ограничение нагрузки к явно выбранному ревьюверу не применяется.
+// Остальные правила те же, что у ReassignReviewer.
+func (s *pullRequestService) ReassignReviewerTo(ctx context.Context, prID, oldReviewerID, newReviewerID string, force bool) (*domain.PullRequest, error) {
+	if newReviewerID == "" {
+		return nil, domain.NewBadRequestError("new_user_id is required")
+	}
+
+	updatedPR, _, err := s.reassign(ctx, prID, oldReviewerID, newReviewerID, force)
+	return updatedPR, err
+}
+
+// reassign заменяет ревьювера oldReviewerID на chosenReviewerID, а если он пуст - на выбранного стратегией
+func (s *pullRequestService) reassign(ctx context.Context, prID, oldReviewerID, chosenReviewerID string, force bool) (*domain.PullRequest, string, error) {
 	pr, err := s.pullRequestRepo.GetByID(ctx, prID)
 	if err != nil {
 		if err.Error() == "pull request not found" {
@@ -131,8 +678,8 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return nil, "", err
 	}
 
-	if pr.Status == domain.StatusMerged {
-		return nil, "", domain.ErrPRMerged
+	if err := requireOpen(pr); err != nil {
+		return nil, "", err
 	}
 
 	isAssigned := false
@@ -147,6 +694,16 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return nil, "", domain.ErrNotAssigned
 	}
 
+	if !force {
+		mandatory, err := s.isMandatoryReviewer(ctx, pr.AuthorID, oldReviewerID)
+		if err != nil {
+			return nil, "", err
+		}
+		if mandatory {
+			return nil, "", domain.ErrMandatoryReviewer
+		}
+	}
+
 	oldReviewer, err := s.userRepo.GetByID(ctx, oldReviewerID)
 	if err != nil {
 		if err.Error() == "user not found" {
@@ -168,28 +725,781 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return nil, "", err
 	}
 
-	selectedReviewers := SelectReviewers(teamMembers, oldReviewerID, 1)
-	if len(selectedReviewers) == 0 {
-		return nil, "", domain.ErrNoCandidate
+	seed := s.seeder.Seed(prID, reassignSeedKey(oldReviewerID, pr.AssignedReviewers)...)
+	draw := s.withDraw(newSeededRand(seed), pr.AuthorID, pr.CoAuthorIDs...)
+
+	event := domain.AssignmentReassign
+	newReviewerID := chosenReviewerID
+	if chosenReviewerID == "" {
+		newReviewerID, err = draw.selectReplacement(ctx, pr, team, teamMembers)
+	} else {
+		event = domain.AssignmentManualReassign
+		err = s.validateChosenReviewer(ctx, pr, team, chosenReviewerID)
+	}
+	if err != nil {
+		return nil, "", err
 	}
 
-	newReviewerID := selectedReviewers[0]
+	// Замена, добор ревьюверов до team.MinReviewers и запись в историю выбора выполняются в одной транзакции
+	var updatedPR *domain.PullRequest
+	err = draw.inTx(ctx, func(txDraw *pullRequestService) error {
+		err := txDraw.pullRequestRepo.ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID)
+		if err != nil {
+			if err.Error() == "reviewer is not assigned to this PR" {
+				return domain.ErrNotAssigned
+			}
+			return err
+		}
 
-	err = s.pullRequestRepo.ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID)
-	if err != nil {
-		if err.Error() == "reviewer is not assigned to this PR" {
-			return nil, "", domain.ErrNotAssigned
+		updatedPR, err = txDraw.pullRequestRepo.GetByID(ctx, prID)
+		if err != nil {
+			if err.Error() == "pull request not found" {
+				return domain.NewNotFoundError("pull request with id " + prID)
+			}
+			return err
+		}
+
+		refilled, err := txDraw.refillReviewers(ctx, updatedPR, team, teamMembers, oldReviewerID)
+		if err != nil {
+			return err
+		}
+
+		err = txDraw.pullRequestRepo.CreateAssignment(ctx, &domain.Assignment{
+			PullRequestID:      prID,
+			Event:              event,
+			Seed:               seed,
+			ReplacedReviewerID: oldReviewerID,
+			PreviousReviewers:  pr.AssignedReviewers,
+			SelectedReviewers:  append([]string{newReviewerID}, refilled...),
+			Steps:              txDraw.trace.stepsOf(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(refilled) > 0 {
+			updatedPR, err = txDraw.pullRequestRepo.GetByID(ctx, prID)
 		}
+		return err
+	})
+	if err != nil {
 		return nil, "", err
 	}
 
-	updatedPR, err := s.pullRequestRepo.GetByID(ctx, prID)
+	return updatedPR, newReviewerID, nil
+}
+
+// AddReviewer назначает на OPEN PR ревьювера reviewerID, выбранного вручную. Для него действуют те же проверки,
+// что и для явной замены (см. ReassignReviewerTo), относительно команды автора; ревьюверов на PR
+// не может стать больше team.MaxReviewers. Изменение записывается в историю выбора ревьюверов.
+// Проверки, добавление и запись в историю выполняются в одной транзакции под блокировкой строки PR,
+// поэтому параллельные добавления не превысят team.MaxReviewers.
+func (s *pullRequestService) AddReviewer(ctx context.Context, prID, reviewerID string) (*domain.PullRequest, error) {
+	var updatedPR *domain.PullRequest
+	err := s.inTx(ctx, func(txService *pullRequestService) error {
+		var err error
+		updatedPR, err = txService.addReviewer(ctx, prID, reviewerID)
+		return err
+	})
 	if err != nil {
-		if err.Error() == "pull request not found" {
-			return nil, "", domain.NewNotFoundError("pull request with id " + prID)
+		return nil, err
+	}
+
+	return updatedPR, nil
+}
+
+// addReviewer выполняет AddReviewer в транзакции сервиса
+func (s *pullRequestService) addReviewer(ctx context.Context, prID, reviewerID string) (*domain.PullRequest, error) {
+	pr, err := s.pullRequestRepo.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
 		}
-		return nil, "", err
+		return nil, err
 	}
 
-	return updatedPR, newReviewerID, nil
+	if err := requireOpen(pr); err != nil {
+		return nil, err
+	}
+
+	team, err := s.authorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pr.AssignedReviewers) >= team.MaxReviewers {
+		return nil, domain.NewReviewerLimitError("PR already has max_reviewers reviewers")
+	}
+
+	err = s.validateChosenReviewer(ctx, pr, team, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pullRequestRepo.AddReviewer(ctx, prID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pullRequestRepo.CreateAssignment(ctx, &domain.Assignment{
+		PullRequestID:     prID,
+		Event:             domain.AssignmentAddReviewer,
+		PreviousReviewers: pr.AssignedReviewers,
+		SelectedReviewers: []string{reviewerID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetByID(ctx, prID)
+}
+
+// RemoveReviewer снимает ревьювера reviewerID с OPEN PR без замены. Ревьюверов на PR не может стать
+// меньше team.MinReviewers команды автора; обязательного ревьювера команды можно снять только с force = true.
+// Изменение записывается в историю выбора ревьюверов. Как и AddReviewer, выполняется в одной транзакции
+// под блокировкой строки PR, поэтому параллельные снятия не оставят на PR меньше team.MinReviewers.
+func (s *pullRequestService) RemoveReviewer(ctx context.Context, prID, reviewerID string, force bool) (*domain.PullRequest, error) {
+	var updatedPR *domain.PullRequest
+	err := s.inTx(ctx, func(txService *pullRequestService) error {
+		var err error
+		updatedPR, err = txService.removeReviewer(ctx, prID, reviewerID, force)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedPR, nil
+}
+
+// removeReviewer выполняет RemoveReviewer в транзакции сервиса
+func (s *pullRequestService) removeReviewer(ctx context.Context, prID, reviewerID string, force bool) (*domain.PullRequest, error) {
+	pr, err := s.pullRequestRepo.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	if err := requireOpen(pr); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, domain.ErrNotAssigned
+	}
+
+	team, err := s.authorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		mandatoryReviewers, err := s.teamRepo.GetMandatoryReviewers(ctx, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(mandatoryReviewers, reviewerID) {
+			return nil, domain.ErrMandatoryReviewer
+		}
+	}
+
+	if len(pr.AssignedReviewers) <= team.MinReviewers {
+		return nil, domain.NewReviewerLimitError("PR cannot have fewer than min_reviewers reviewers")
+	}
+
+	err = s.pullRequestRepo.RemoveReviewer(ctx, prID, reviewerID)
+	if err != nil {
+		if err.Error() == "reviewer not assigned to this PR" {
+			return nil, domain.ErrNotAssigned
+		}
+		return nil, err
+	}
+
+	err = s.pullRequestRepo.CreateAssignment(ctx, &domain.Assignment{
+		PullRequestID:      prID,
+		Event:              domain.AssignmentRemoveReviewer,
+		ReplacedReviewerID: reviewerID,
+		PreviousReviewers:  pr.AssignedReviewers,
+		SelectedReviewers:  []string{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetByID(ctx, prID)
+}
+
+// SubmitReview сохраняет решение state ревьювера reviewerID по OPEN PR. Решение может отправить только
+// назначенный ревьювер; повторные решения сохраняются, актуальным считается последнее.
+func (s *pullRequestService) SubmitReview(ctx context.Context, prID, reviewerID string, state domain.ReviewState) (*domain.PullRequest, error) {
+	if !state.IsValid() {
+		return nil, domain.NewBadRequestError("state must be one of APPROVED, CHANGES_REQUESTED, COMMENTED")
+	}
+
+	pr, err := s.pullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	if err := requireOpen(pr); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, domain.ErrNotAssigned
+	}
+
+	err = s.pullRequestRepo.CreateReview(ctx, &domain.Review{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		State:         state,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetByID(ctx, prID)
+}
+
+// authorTeam возвращает команду автора authorID, ограничения которой действуют для его PR
+func (s *pullRequestService) authorTeam(ctx context.Context, authorID string) (*domain.Team, error) {
+	author, err := s.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + authorID)
+		}
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + author.TeamName)
+		}
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// validateChosenReviewer проверяет, что userID можно назначить на PR вместо ревьювера из команды team
+func (s *pullRequestService) validateChosenReviewer(ctx context.Context, pr *domain.PullRequest, team *domain.Team, userID string) error {
+	if userID == pr.AuthorID {
+		return domain.NewInvalidReviewerError("user is the PR author")
+	}
+	if slices.Contains(pr.CoAuthorIDs, userID) {
+		return domain.NewInvalidReviewerError("user is a co-author of the PR")
+	}
+	if slices.Contains(pr.AssignedReviewers, userID) {
+		return domain.NewInvalidReviewerError("user is already assigned to this PR")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+			return domain.NewNotFoundError("user with id " + userID)
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return domain.NewInvalidReviewerError("user is inactive")
+	}
+	if user.Unavailable {
+		return domain.NewInvalidReviewerError("user is unavailable")
+	}
+	if user.TeamID == team.ID {
+		return nil
+	}
+
+	fallbackTeams, err := s.teamRepo.GetFallbackTeams(ctx, team.ID)
+	if err != nil {
+		return err
+	}
+	for _, fallbackTeam := range fallbackTeams {
+		if user.TeamID == fallbackTeam.ID {
+			return nil
+		}
+	}
+
+	return domain.NewInvalidReviewerError("user is not in team " + team.Name + " or its fallback teams")
+}
+
+// isMandatoryReviewer сообщает, является ли reviewerID обязательным ревьювером команды автора authorID
+func (s *pullRequestService) isMandatoryReviewer(ctx context.Context, authorID, reviewerID string) (bool, error) {
+	author, err := s.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return false, domain.NewNotFoundError("user with id " + authorID)
+		}
+		return false, err
+	}
+
+	mandatoryReviewers, err := s.teamRepo.GetMandatoryReviewers(ctx, author.TeamID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(mandatoryReviewers, reviewerID), nil
+}
+
+// selectMandatory выбирает обязательных ревьюверов команды team, кроме автора и соавторов authorIDs.
+// Ограничение нагрузки к ним не применяется; неактивные и отсутствующие сейчас пропускаются.
+func (s *pullRequestService) selectMandatory(ctx context.Context, team *domain.Team, authorIDs []string) ([]string, error) {
+	mandatoryIDs, err := s.teamRepo.GetMandatoryReviewers(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mandatoryIDs) == 0 {
+		return []string{}, nil
+	}
+
+	mandatoryUsers := make([]*domain.User, 0, len(mandatoryIDs))
+	for _, userID := range mandatoryIDs {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if err.Error() == "user not found" {
+				continue
+			}
+			return nil, err
+		}
+		mandatoryUsers = append(mandatoryUsers, user)
+	}
+
+	excludeUserIDs := authorIDs
+	selectedReviewers := takeIDs(eligibleCandidates(mandatoryUsers, excludeUserIDs), len(mandatoryUsers))
+	if s.trace != nil {
+		s.trace.record(domain.SourceMandatory, team, "", mandatoryUsers, mandatoryUsers, excludeUserIDs, selectedReviewers, nil)
+	}
+
+	return selectedReviewers, nil
+}
+
+// selectReplacement выбирает замену ревьюверу PR из teamMembers команды team или ее резервных команд.
+// Автор, соавторы и уже назначенные ревьюверы не выбираются.
+func (s *pullRequestService) selectReplacement(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	team *domain.Team,
+	teamMembers []*domain.User,
+) (string, error) {
+	excludeUserIDs := append(pr.AuthorIDs(), pr.AssignedReviewers...)
+	selectedReviewers, saturated, err := s.selectWithFallback(ctx, team, teamMembers, excludeUserIDs, pr.Tags, 1, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(selectedReviewers) == 0 {
+		if saturated > 0 {
+			return "", domain.NewNoCandidateError("all candidates are at review capacity")
+		}
+		return "", domain.ErrNoCandidate
+	}
+
+	return selectedReviewers[0], nil
+}
+
+// refillReviewers добирает ревьюверов на PR, выбранных selectRefill, и возвращает их
+func (s *pullRequestService) refillReviewers(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	team *domain.Team,
+	teamMembers []*domain.User,
+	excludeUserIDs ...string,
+) ([]string, error) {
+	selectedReviewers, err := s.selectRefill(ctx, pr, team, teamMembers, excludeUserIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reviewerID := range selectedReviewers {
+		if err := s.pullRequestRepo.AddReviewer(ctx, pr.ID, reviewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return selectedReviewers, nil
+}
+
+// selectRefill выбирает ревьюверов из teamMembers и резервных команд, которых не хватает на PR до team.MinReviewers.
+// Автор, соавторы, уже назначенные ревьюверы и excludeUserIDs не выбираются.
+func (s *pullRequestService) selectRefill(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	team *domain.Team,
+	teamMembers []*domain.User,
+	excludeUserIDs ...string,
+) ([]string, error) {
+	missing := team.MinReviewers - len(pr.AssignedReviewers)
+	if missing <= 0 {
+		return []string{}, nil
+	}
+
+	excludeUserIDs = append(excludeUserIDs, pr.AuthorIDs()...)
+	excludeUserIDs = append(excludeUserIDs, pr.AssignedReviewers...)
+	selectedReviewers, _, err := s.selectWithFallback(ctx, team, teamMembers, excludeUserIDs, pr.Tags, missing, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectedReviewers, nil
+}
+
+// ExplainAssignment возвращает историю выбора ревьюверов на PR. Каждый выбор повторяется с сохраненным seed
+// на входных данных, записанных в его трассировке (кандидаты, веса, нагрузка, курсор ротации, история ревью),
+// без чтения текущих данных БД; если результат совпал с сохраненным, выбор воспроизведен.
+func (s *pullRequestService) ExplainAssignment(ctx context.Context, prID string) ([]*domain.AssignmentReplay, error) {
+	_, err := s.pullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	assignments, err := s.pullRequestRepo.GetAssignments(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	replays := make([]*domain.AssignmentReplay, 0, len(assignments))
+	for _, assignment := range assignments {
+		replayed, ok, err := replayAssignment(ctx, assignment)
+		if err != nil {
+			return nil, err
+		}
+
+		replays = append(replays, &domain.AssignmentReplay{
+			Assignment:        assignment,
+			ReplayedReviewers: replayed,
+			Reproduced:        ok && slices.Equal(replayed, assignment.SelectedReviewers),
+		})
+	}
+
+	return replays, nil
+}
+
+// GetAssignments возвращает историю выбора ревьюверов на PR с трассировкой каждого выбора
+func (s *pullRequestService) GetAssignments(ctx context.Context, prID string) ([]*domain.Assignment, error) {
+	_, err := s.pullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		if err.Error() == "pull request not found" || err.Error() == "invalid pull request ID" {
+			return nil, domain.NewNotFoundError("pull request with id " + prID)
+		}
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetAssignments(ctx, prID)
+}
+
+const (
+	defaultSimulationIterations = 100
+	maxSimulationIterations     = 1000
+)
+
+// SimulateSelection повторяет выбор ревьюверов для гипотетического PR input.Iterations раз
+// (по умолчанию defaultSimulationIterations) и возвращает, сколько раз был выбран каждый пользователь.
+// Выбор идет так же, как при создании PR, но в БД ничего не записывается: состояние стратегий
+// (курсор round_robin) переходит от повтора к повтору только в памяти, начиная с текущего,
+// а least_loaded прибавляет к нагрузке из БД выборы предыдущих повторов.
+func (s *pullRequestService) SimulateSelection(ctx context.Context, input SimulationInput) (*domain.SelectionSimulation, error) {
+	if (input.AuthorID == "") == (input.TeamName == "") {
+		return nil, domain.NewBadRequestError("exactly one of author_id or team_name is required")
+	}
+
+	iterations := input.Iterations
+	if iterations == 0 {
+		iterations = defaultSimulationIterations
+	}
+	if iterations < 0 || iterations > maxSimulationIterations {
+		return nil, domain.NewBadRequestError("iterations must be in range [1, 1000]")
+	}
+
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName := input.TeamName
+	if input.AuthorID != "" {
+		author, err := s.userRepo.GetByID(ctx, input.AuthorID)
+		if err != nil {
+			if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+				return nil, domain.NewNotFoundError("user with id " + input.AuthorID)
+			}
+			return nil, err
+		}
+		teamName = author.TeamName
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + teamName)
+		}
+		return nil, err
+	}
+
+	teamMembers, err := s.userRepo.GetByTeamID(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	createInput := CreatePRInput{
+		AuthorID:     input.AuthorID,
+		Repository:   input.Repository,
+		ChangedFiles: input.ChangedFiles,
+		Tags:         tags,
+	}
+
+	simulation := *s
+	simulation.selector = selectorForSimulation(s.selector)
+
+	rng := newSeededRand(time.Now().UnixNano())
+	counts := make(map[string]int)
+	noCandidate := 0
+	for i := 0; i < iterations; i++ {
+		selectedReviewers, err := simulation.withDraw(rng, input.AuthorID).selectForCreate(ctx, createInput, team, teamMembers, tags)
+		if err != nil && !errors.Is(err, domain.ErrNoCandidate) {
+			return nil, err
+		}
+		if len(selectedReviewers) == 0 {
+			noCandidate++
+			continue
+		}
+		for _, reviewerID := range selectedReviewers {
+			counts[reviewerID]++
+		}
+	}
+
+	picks := make([]*domain.ReviewerPicks, 0, len(counts))
+	for userID, count := range counts {
+		picks = append(picks, &domain.ReviewerPicks{UserID: userID, Count: count})
+	}
+	sort.Slice(picks, func(i, j int) bool {
+		if picks[i].Count != picks[j].Count {
+			return picks[i].Count > picks[j].Count
+		}
+		return picks[i].UserID < picks[j].UserID
+	})
+
+	return &domain.SelectionSimulation{
+		AuthorID:    input.AuthorID,
+		TeamName:    team.Name,
+		Strategy:    s.selector.Strategy(team),
+		Iterations:  iterations,
+		NoCandidate: noCandidate,
+		Picks:       picks,
+	}, nil
+}
+
+// selectWithFallback выбирает до want ревьюверов из teamMembers команды team, отдавая предпочтение
+// кандидатам с навыками tags.
+// Если выбрано меньше need, недостающие добираются из резервных команд team в порядке приоритета;
+// к каждой резервной команде применяется ее собственная стратегия выбора.
+// Вторым значением возвращается количество кандидатов, пропущенных из-за ограничения нагрузки.
+func (s *pullRequestService) selectWithFallback(
+	ctx context.Context,
+	team *domain.Team,
+	teamMembers []*domain.User,
+	excludeUserIDs []string,
+	tags []string,
+	want, need int,
+) ([]string, int, error) {
+	if want <= 0 {
+		return []string{}, 0, nil
+	}
+
+	candidates, saturated, err := withoutSaturated(ctx, s.pullRequestRepo, teamMembers, excludeUserIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	selectedReviewers, inputs, err := s.selectBySkills(ctx, SelectionRequest{
+		Team:           team,
+		TeamMembers:    candidates,
+		ExcludeUserIDs: excludeUserIDs,
+		MaxReviewers:   want,
+	}, tags)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.traceStep(domain.SourceTeam, team, teamMembers, candidates, excludeUserIDs, selectedReviewers, inputs)
+	if len(selectedReviewers) >= need {
+		return selectedReviewers, saturated, nil
+	}
+
+	fallbackTeams, err := s.teamRepo.GetFallbackTeams(ctx, team.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, fallbackTeam := range fallbackTeams {
+		if len(selectedReviewers) >= need {
+			break
+		}
+
+		fallbackMembers, err := s.userRepo.GetByTeamID(ctx, fallbackTeam.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		fallbackExclude := append(append([]string{}, excludeUserIDs...), selectedReviewers...)
+		fallbackCandidates, fallbackSaturated, err := withoutSaturated(ctx, s.pullRequestRepo, fallbackMembers, fallbackExclude)
+		if err != nil {
+			return nil, 0, err
+		}
+		saturated += fallbackSaturated
+
+		fallbackSelected, fallbackInputs, err := s.selectBySkills(ctx, SelectionRequest{
+			Team:           fallbackTeam,
+			TeamMembers:    fallbackCandidates,
+			ExcludeUserIDs: fallbackExclude,
+			MaxReviewers:   need - len(selectedReviewers),
+		}, tags)
+		if err != nil {
+			return nil, 0, err
+		}
+		s.traceStep(domain.SourceFallbackTeam, fallbackTeam, fallbackMembers, fallbackCandidates, fallbackExclude, fallbackSelected, fallbackInputs)
+		selectedReviewers = append(selectedReviewers, fallbackSelected...)
+	}
+
+	return selectedReviewers, saturated, nil
+}
+
+// selectCodeOwners выбирает на оставшиеся до team.MaxReviewers места ревьюверов из владельцев измененных файлов
+// по CODEOWNERS репозитория, кроме уже выбранных selectedReviewers. Владельцы-команды раскрываются в своих участников;
+// к кандидатам применяются те же фильтры активности, отсутствия и нагрузки, что и к команде автора.
+// Владельцы могут быть из других команд, поэтому стратегия команды автора применяется к ним без изменения
+// своего состояния: курсор ротации команды сдвигается только выбором из ее участников.
+// Если репозиторий или файлы не заданы либо CODEOWNERS для репозитория не загружен, возвращается пустой список.
+func (s *pullRequestService) selectCodeOwners(
+	ctx context.Context,
+	input CreatePRInput,
+	team *domain.Team,
+	selectedReviewers []string,
+	tags []string,
+) ([]string, int, error) {
+	want := team.MaxReviewers - len(selectedReviewers)
+	if input.Repository == "" || len(input.ChangedFiles) == 0 || want <= 0 {
+		return []string{}, 0, nil
+	}
+
+	codeOwners, err := s.codeOwnersRepo.GetByRepository(ctx, input.Repository)
+	if err != nil {
+		if err.Error() == "codeowners not found" {
+			return []string{}, 0, nil
+		}
+		return nil, 0, err
+	}
+
+	ruleset, err := codeowners.Parse(codeOwners.Content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	owners, err := s.resolveOwners(ctx, ruleset.OwnersOf(input.ChangedFiles))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	excludeUserIDs := append(append([]string{input.AuthorID}, input.CoAuthorIDs...), selectedReviewers...)
+	candidates, saturated, err := withoutSaturated(ctx, s.pullRequestRepo, owners, excludeUserIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ownerReviewers, inputs, err := s.selectBySkills(ctx, SelectionRequest{
+		Team:           team,
+		TeamMembers:    candidates,
+		ExcludeUserIDs: excludeUserIDs,
+		MaxReviewers:   want,
+		DryRun:         true,
+	}, tags)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.traceStep(domain.SourceCodeOwners, team, owners, candidates, excludeUserIDs, ownerReviewers, inputs)
+
+	return ownerReviewers, saturated, nil
+}
+
+// resolveOwners раскрывает владельцев из CODEOWNERS в пользователей без повторов.
+// Пользователи и команды, которых нет в системе, пропускаются.
+func (s *pullRequestService) resolveOwners(ctx context.Context, owners []codeowners.Owner) ([]*domain.User, error) {
+	seen := make(map[string]bool)
+	users := make([]*domain.User, 0)
+	addUser := func(user *domain.User) {
+		if !seen[user.ID] {
+			seen[user.ID] = true
+			users = append(users, user)
+		}
+	}
+
+	for _, owner := range owners {
+		if !owner.IsTeam() {
+			user, err := s.userRepo.GetByID(ctx, owner.UserID)
+			if err != nil {
+				if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+					continue
+				}
+				return nil, err
+			}
+			addUser(user)
+			continue
+		}
+
+		ownerTeam, err := s.teamRepo.GetByName(ctx, owner.TeamName)
+		if err != nil {
+			if err.Error() == "team not found" {
+				continue
+			}
+			return nil, err
+		}
+
+		members, err := s.userRepo.GetByTeamID(ctx, ownerTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			addUser(member)
+		}
+	}
+
+	return users, nil
+}
+
+// selectBySkills выбирает ревьюверов стратегией s.selector, сначала среди кандидатов,
+// чьи навыки покрывают все теги tags. Остальные кандидаты выбираются, только если подходящих
+// по навыкам не хватает; если tags пуст, выбор идет среди всех кандидатов.
+// Стратегия вызывается один раз, поэтому курсор ротации сдвигается один раз за выбор.
+// Вторым значением возвращаются входные данные выбора для трассировки.
+func (s *pullRequestService) selectBySkills(ctx context.Context, req SelectionRequest, tags []string) ([]string, *domain.SelectionInputs, error) {
+	req.Rand = s.rng
+	req.AuthorID = s.trace.authorOf()
+
+	candidates := eligibleCandidates(req.TeamMembers, req.ExcludeUserIDs)
+	if len(tags) > 0 && req.MaxReviewers > 0 {
+		if err := loadSkills(ctx, s.userRepo, candidates); err != nil {
+			return nil, nil, err
+		}
+
+		for _, candidate := range candidates {
+			if candidate.HasSkills(tags) {
+				req.PreferredUserIDs = append(req.PreferredUserIDs, candidate.ID)
+			}
+		}
+	}
+
+	req.Inputs = newSelectionInputs(req, candidates)
+	selectedReviewers, err := s.selector.Select(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return selectedReviewers, req.Inputs, nil
 }
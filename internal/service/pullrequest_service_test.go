@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/mocks"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
 		prID := "pr-1"
 		title := "Add feature"
@@ -35,8 +36,10 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		}
 
 		team := &domain.Team{
-			ID:   1,
-			Name: "backend",
+			ID:           1,
+			Name:         "backend",
+			MinReviewers: 1,
+			MaxReviewers: 2,
 			Members: []domain.TeamMember{
 				{UserID: "u1", Username: "Alice", IsActive: true},
 				{UserID: "u2", Username: "Bob", IsActive: true},
@@ -54,8 +57,10 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockPRRepo.On("GetByID", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
 		mockUserRepo.On("GetByID", mock.Anything, authorID).Return(author, nil).Once()
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
 		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
 		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
 
 		createdPR := &domain.PullRequest{
 			ID:                prID,
@@ -67,7 +72,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		}
 		mockPRRepo.On("GetByID", mock.Anything, prID).Return(createdPR, nil).Once()
 
-		result, err := service.CreatePR(context.Background(), prID, title, authorID)
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: prID, Title: title, AuthorID: authorID})
 
 		require.NoError(t, err)
 		assert.Equal(t, prID, result.ID)
@@ -86,7 +91,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
 		prID := "pr-1"
 		existingPR := &domain.PullRequest{
@@ -98,7 +103,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 
 		mockPRRepo.On("GetByID", mock.Anything, prID).Return(existingPR, nil).Once()
 
-		result, err := service.CreatePR(context.Background(), prID, "New PR", "u1")
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: prID, Title: "New PR", AuthorID: "u1"})
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -111,7 +116,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
 		prID := "pr-1"
 		authorID := "u999"
@@ -119,7 +124,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockPRRepo.On("GetByID", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
 		mockUserRepo.On("GetByID", mock.Anything, authorID).Return(nil, errors.New("user not found")).Once()
 
-		result, err := service.CreatePR(context.Background(), prID, "New PR", authorID)
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: prID, Title: "New PR", AuthorID: authorID})
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -133,7 +138,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
 		prID := "pr-1"
 		authorID := "u1"
@@ -151,7 +156,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUserRepo.On("GetByID", mock.Anything, authorID).Return(author, nil).Once()
 		mockTeamRepo.On("GetByName", mock.Anything, "nonexistent").Return(nil, errors.New("team not found")).Once()
 
-		result, err := service.CreatePR(context.Background(), prID, "New PR", authorID)
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: prID, Title: "New PR", AuthorID: authorID})
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -166,7 +171,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
 		prID := "pr-1"
 		title := "Add feature"
@@ -182,8 +187,10 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		}
 
 		team := &domain.Team{
-			ID:   1,
-			Name: "backend",
+			ID:           1,
+			Name:         "backend",
+			MinReviewers: 1,
+			MaxReviewers: 2,
 			Members: []domain.TeamMember{
 				{UserID: "u1", Username: "Alice", IsActive: true},
 			},
@@ -197,8 +204,11 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockPRRepo.On("GetByID", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
 		mockUserRepo.On("GetByID", mock.Anything, authorID).Return(author, nil).Once()
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
 		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil).Once()
 		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
 
 		createdPR := &domain.PullRequest{
 			ID:                prID,
@@ -210,7 +220,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		}
 		mockPRRepo.On("GetByID", mock.Anything, prID).Return(createdPR, nil).Once()
 
-		result, err := service.CreatePR(context.Background(), prID, title, authorID)
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: prID, Title: title, AuthorID: authorID})
 
 		require.NoError(t, err)
 		assert.Equal(t, prID, result.ID)
@@ -221,340 +231,2817 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 	})
 }
 
-func TestPullRequestService_MergePR(t *testing.T) {
-	t.Run("успешный merge PR", func(t *testing.T) {
+func TestPullRequestService_CreatePR_TeamReviewerLimits(t *testing.T) {
+	t.Run("назначается max_reviewers ревьюверов команды", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
-
-		prID := "pr-1"
-		openPR := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusOpen,
-			AssignedReviewers: []string{"u2"},
-			CreatedAt:         time.Now(),
-			MergedAt:          nil,
-		}
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
-		mergedTime := time.Now()
-		mergedPR := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusMerged,
-			AssignedReviewers: []string{"u2"},
-			CreatedAt:         openPR.CreatedAt,
-			MergedAt:          &mergedTime,
+		author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "platform", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "platform", MinReviewers: 2, MaxReviewers: 3}
+		teamMembers := []*domain.User{
+			{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u5", Username: "Eve", TeamID: 1, TeamName: "platform", IsActive: true},
 		}
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(openPR, nil).Once()
-		mockPRRepo.On("UpdateStatus", mock.Anything, prID, domain.StatusMerged, mock.AnythingOfType("*time.Time")).Return(nil).Once()
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(mergedPR, nil).Once()
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
 
-		result, err := service.MergePR(context.Background(), prID)
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
 
 		require.NoError(t, err)
-		assert.Equal(t, domain.StatusMerged, result.Status)
-		assert.NotNil(t, result.MergedAt)
+		require.NotNil(t, createdPR)
+		assert.Len(t, createdPR.AssignedReviewers, 3)
+		assert.NotContains(t, createdPR.AssignedReviewers, "u1")
 		mockPRRepo.AssertExpectations(t)
 	})
+}
 
-	t.Run("идемпотентность: PR уже в статусе MERGED", func(t *testing.T) {
+func TestPullRequestService_CreatePR_MandatoryReviewers(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+	champion := &domain.User{ID: "u7", Username: "Grace", TeamID: 3, TeamName: "security", IsActive: true}
+
+	t.Run("обязательный ревьювер назначается, остальные места заполняются из команды", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
-		prID := "pr-1"
-		mergedTime := time.Now()
-		mergedPR := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusMerged,
-			AssignedReviewers: []string{"u2"},
-			CreatedAt:         time.Now(),
-			MergedAt:          &mergedTime,
-		}
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{"u7"}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u7").Return(champion, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return len(a.Steps) == 2 && a.Steps[0].Source == domain.SourceMandatory &&
+				assert.ObjectsAreEqual([]string{"u7"}, a.Steps[0].Selected)
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(mergedPR, nil).Once()
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		require.Len(t, createdPR.AssignedReviewers, 2)
+		assert.Equal(t, "u7", createdPR.AssignedReviewers[0])
+		assert.Contains(t, []string{"u2", "u3"}, createdPR.AssignedReviewers[1])
+		mockPRRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+	})
 
-		result, err := service.MergePR(context.Background(), prID)
+	t.Run("автор не назначается своим обязательным ревьювером", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Twice()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{"u1"}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
 
 		require.NoError(t, err)
-		assert.Equal(t, domain.StatusMerged, result.Status)
+		require.NotNil(t, createdPR)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, createdPR.AssignedReviewers)
 		mockPRRepo.AssertExpectations(t)
 	})
+}
 
-	t.Run("ошибка: PR не найден", func(t *testing.T) {
+func TestPullRequestService_CreatePR_CoAuthors(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("соавтор сохраняется на PR и не назначается ревьювером", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
-		prID := "pr-999"
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(teamMembers[1], nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return assert.ObjectsAreEqual([]string{"u2"}, pr.CoAuthorIDs) &&
+				assert.ObjectsAreEqual([]string{"u3"}, pr.AssignedReviewers)
+		})).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return len(a.Steps) == 1 && len(a.Steps[0].Excluded) == 2 &&
+				a.Steps[0].Excluded[1].UserID == "u2" && a.Steps[0].Excluded[1].Reason == domain.ExclusionCoAuthor
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", CoAuthorIDs: []string{"u2"}, Status: domain.StatusOpen, AssignedReviewers: []string{"u3"}}, nil).Once()
+
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Pair work", AuthorID: "u1", CoAuthorIDs: []string{"u2"}})
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, result.CoAuthorIDs)
+		assert.Equal(t, []string{"u3"}, result.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: автор указан соавтором", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
 
-		result, err := service.MergePR(context.Background(), prID)
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", AuthorID: "u1", CoAuthorIDs: []string{"u1"}})
 
 		require.Error(t, err)
 		assert.Nil(t, result)
-		assert.True(t, errors.Is(err, domain.ErrNotFound))
-		mockPRRepo.AssertExpectations(t)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
-}
 
-func TestPullRequestService_ReassignReviewer(t *testing.T) {
-	t.Run("успешное переназначение ревьювера", func(t *testing.T) {
+	t.Run("ошибка: соавтор не найден", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, nil, nil, NewRandomSelector(), nil)
 
-		prID := "pr-1"
-		oldReviewerID := "u2"
-		newReviewerID := "u3"
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
 
-		pr := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusOpen,
-			AssignedReviewers: []string{oldReviewerID, "u4"},
-			CreatedAt:         time.Now(),
-			MergedAt:          nil,
-		}
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", AuthorID: "u1", CoAuthorIDs: []string{"u999"}})
 
-		oldReviewer := &domain.User{
-			ID:        oldReviewerID,
-			Username:  "Bob",
-			TeamID:    1,
-			TeamName:  "backend",
-			IsActive:  true,
-			CreatedAt: time.Now(),
-		}
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
 
-		team := &domain.Team{
-			ID:   1,
-			Name: "backend",
-			Members: []domain.TeamMember{
-				{UserID: "u2", Username: "Bob", IsActive: true},
-				{UserID: "u3", Username: "Charlie", IsActive: true},
-				{UserID: "u5", Username: "Dave", IsActive: true},
-			},
-			CreatedAt: time.Now(),
-		}
+func TestPullRequestService_ReassignReviewer_CoAuthors(t *testing.T) {
+	t.Run("соавтор не выбирается заменой", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
 
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
 		teamMembers := []*domain.User{
+			{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true},
 			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
 			{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
-			{ID: "u5", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
-		}
-
-		updatedPR := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusOpen,
-			AssignedReviewers: []string{newReviewerID, "u4"},
-			CreatedAt:         pr.CreatedAt,
-			MergedAt:          nil,
+			{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
 		}
+		pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", CoAuthorIDs: []string{"u3"}, Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
-		mockUserRepo.On("GetByID", mock.Anything, oldReviewerID).Return(oldReviewer, nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(teamMembers[1], nil).Once()
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
 		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
-		mockPRRepo.On("ReplaceReviewer", mock.Anything, prID, oldReviewerID, mock.AnythingOfType("string")).Return(nil).Once()
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(updatedPR, nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u2", "u4").Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", CoAuthorIDs: []string{"u3"}, Status: domain.StatusOpen, AssignedReviewers: []string{"u4"}}, nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
 
-		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID)
+		_, newReviewer, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", true)
 
 		require.NoError(t, err)
-		assert.Equal(t, prID, result.ID)
-		assert.NotEmpty(t, newReviewer)
-		assert.NotEqual(t, oldReviewerID, newReviewer)
-		assert.NotContains(t, result.AssignedReviewers, oldReviewerID)
+		assert.Equal(t, "u4", newReviewer)
 		mockPRRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
-		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("ошибка: PR не найден", func(t *testing.T) {
+	t.Run("ошибка: соавтора нельзя выбрать явно", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
-
-		prID := "pr-999"
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", CoAuthorIDs: []string{"u3"}, Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(&domain.User{ID: "u2", TeamID: 1, TeamName: "backend", IsActive: true}, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return([]*domain.User{}, nil).Once()
 
-		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, "u2")
+		result, err := service.ReassignReviewerTo(context.Background(), "pr-1", "u2", "u3", true)
 
 		require.Error(t, err)
 		assert.Nil(t, result)
-		assert.Empty(t, newReviewer)
-		assert.True(t, errors.Is(err, domain.ErrNotFound))
-		mockPRRepo.AssertExpectations(t)
+		assert.True(t, errors.Is(err, domain.ErrInvalidReviewer))
+		mockPRRepo.AssertNotCalled(t, "ReplaceReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
+}
 
-	t.Run("ошибка: PR уже в статусе MERGED", func(t *testing.T) {
+func TestPullRequestService_CreatePR_FallbackTeams(t *testing.T) {
+	t.Run("недостающие ревьюверы добираются из резервной команды", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
-		prID := "pr-1"
-		mergedTime := time.Now()
-		mergedPR := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusMerged,
-			AssignedReviewers: []string{"u2"},
-			CreatedAt:         time.Now(),
-			MergedAt:          &mergedTime,
+		author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 2, MaxReviewers: 2}
+		teamMembers := []*domain.User{
+			author,
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		}
+		fallbackTeam := &domain.Team{ID: 2, Name: "platform", MinReviewers: 1, MaxReviewers: 2}
+		fallbackMembers := []*domain.User{
+			{ID: "u5", Username: "Eve", TeamID: 2, TeamName: "platform", IsActive: true},
+			{ID: "u6", Username: "Frank", TeamID: 2, TeamName: "platform", IsActive: false},
 		}
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(mergedPR, nil).Once()
-
-		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, "u2")
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{fallbackTeam}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 2).Return(fallbackMembers, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AssignedReviewers: []string{"u2", "u5"},
+			ReviewerTeams:     map[string]string{"u2": "backend", "u5": "platform"},
+		}, nil).Once()
+
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
 
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Empty(t, newReviewer)
-		assert.True(t, errors.Is(err, domain.ErrPRMerged))
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		assert.Equal(t, []string{"u2", "u5"}, createdPR.AssignedReviewers)
+		assert.Equal(t, "platform", result.ReviewerTeams["u5"])
 		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("ошибка: ревьювер не назначен на PR", func(t *testing.T) {
+	t.Run("резервные команды не запрашиваются, если своей команды достаточно", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
 
-		prID := "pr-1"
-		pr := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusOpen,
-			AssignedReviewers: []string{"u2"},
-			CreatedAt:         time.Now(),
-			MergedAt:          nil,
+		author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+		teamMembers := []*domain.User{
+			author,
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
 		}
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
 
-		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, "u999")
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
 
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Empty(t, newReviewer)
-		assert.True(t, errors.Is(err, domain.ErrNotAssigned))
-		mockPRRepo.AssertExpectations(t)
+		require.NoError(t, err)
+		mockTeamRepo.AssertNotCalled(t, "GetFallbackTeams", mock.Anything, mock.Anything)
 	})
+}
 
-	t.Run("ошибка: нет доступных кандидатов для замены", func(t *testing.T) {
+func TestPullRequestService_CreatePR_CodeOwners(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+	content := `
+*                @u2
+/migrations/     @u7 @org/dba
+`
+
+	t.Run("владельцы измененных путей выбираются раньше команды автора", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockCodeOwnersRepo := new(mocks.MockCodeOwnersRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
-
-		prID := "pr-1"
-		oldReviewerID := "u2"
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, mockCodeOwnersRepo, NewRandomSelector(), nil)
 
-		pr := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusOpen,
-			AssignedReviewers: []string{oldReviewerID},
-			CreatedAt:         time.Now(),
-			MergedAt:          nil,
-		}
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockCodeOwnersRepo.On("GetByRepository", mock.Anything, "avito/pr-reviewer").
+			Return(&domain.CodeOwners{Repository: "avito/pr-reviewer", Content: content}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u7").
+			Return(&domain.User{ID: "u7", Username: "Grace", TeamID: 3, TeamName: "dba", IsActive: false}, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "dba").Return(&domain.Team{ID: 3, Name: "dba"}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 3).Return([]*domain.User{
+			{ID: "u7", Username: "Grace", TeamID: 3, TeamName: "dba", IsActive: false},
+			{ID: "u8", Username: "Heidi", TeamID: 3, TeamName: "dba", IsActive: true},
+		}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add migration",
+			AuthorID:      "u1",
+			Repository:    "avito/pr-reviewer",
+			ChangedFiles:  []string{"migrations/000008_repository_codeowners.up.sql"},
+		})
 
-		oldReviewer := &domain.User{
-			ID:        oldReviewerID,
-			Username:  "Bob",
-			TeamID:    1,
-			TeamName:  "backend",
-			IsActive:  true,
-			CreatedAt: time.Now(),
-		}
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		require.Len(t, createdPR.AssignedReviewers, 2)
+		assert.Equal(t, "u8", createdPR.AssignedReviewers[0], "неактивный владелец u7 пропускается")
+		assert.Contains(t, []string{"u2", "u3"}, createdPR.AssignedReviewers[1])
+		mockUserRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+		mockCodeOwnersRepo.AssertExpectations(t)
+	})
 
-		team := &domain.Team{
-			ID:   1,
-			Name: "backend",
-			Members: []domain.TeamMember{
-				{UserID: "u2", Username: "Bob", IsActive: true},
-			},
-			CreatedAt: time.Now(),
-		}
+	t.Run("round_robin: выбор владельцев не сдвигает курсор команды автора", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockCodeOwnersRepo := new(mocks.MockCodeOwnersRepository)
+		mockRotationRepo := new(mocks.MockRotationRepository)
 
-		teamMembers := []*domain.User{
-			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
-		}
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, mockCodeOwnersRepo, NewRoundRobinSelector(mockRotationRepo), nil)
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
-		mockUserRepo.On("GetByID", mock.Anything, oldReviewerID).Return(oldReviewer, nil).Once()
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
 		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockCodeOwnersRepo.On("GetByRepository", mock.Anything, "avito/pr-reviewer").
+			Return(&domain.CodeOwners{Repository: "avito/pr-reviewer", Content: content}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u7").
+			Return(&domain.User{ID: "u7", Username: "Grace", TeamID: 3, TeamName: "dba", IsActive: false}, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "dba").Return(&domain.Team{ID: 3, Name: "dba"}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 3).Return([]*domain.User{
+			{ID: "u7", Username: "Grace", TeamID: 3, TeamName: "dba", IsActive: false},
+			{ID: "u8", Username: "Heidi", TeamID: 3, TeamName: "dba", IsActive: true},
+		}, nil).Once()
+		mockRotationRepo.On("GetCursor", mock.Anything, 1).Return("u2", nil).Once()
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("u2", nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add migration",
+			AuthorID:      "u1",
+			Repository:    "avito/pr-reviewer",
+			ChangedFiles:  []string{"migrations/000008_repository_codeowners.up.sql"},
+		})
 
-		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID)
-
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Empty(t, newReviewer)
-		assert.True(t, errors.Is(err, domain.ErrNoCandidate))
-		mockPRRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
-		mockTeamRepo.AssertExpectations(t)
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		assert.Equal(t, []string{"u8", "u3"}, createdPR.AssignedReviewers)
+		assert.Equal(t, "u3", mockRotationRepo.Cursor, "курсор сдвигается только на участника команды автора")
+		mockRotationRepo.AssertExpectations(t)
 	})
 
-	t.Run("ошибка: старый ревьювер не найден", func(t *testing.T) {
+	t.Run("CODEOWNERS не загружен: ревьюверы выбираются из команды автора", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockCodeOwnersRepo := new(mocks.MockCodeOwnersRepository)
 
-		service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, mockCodeOwnersRepo, NewRandomSelector(), nil)
 
-		prID := "pr-1"
-		oldReviewerID := "u999"
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockCodeOwnersRepo.On("GetByRepository", mock.Anything, "unknown").Return(nil, errors.New("codeowners not found")).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add feature",
+			AuthorID:      "u1",
+			Repository:    "unknown",
+			ChangedFiles:  []string{"main.go"},
+		})
 
-		pr := &domain.PullRequest{
-			ID:                prID,
-			Title:             "Add feature",
-			AuthorID:          "u1",
-			Status:            domain.StatusOpen,
-			AssignedReviewers: []string{oldReviewerID},
-			CreatedAt:         time.Now(),
-			MergedAt:          nil,
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, createdPR.AssignedReviewers)
+		mockCodeOwnersRepo.AssertExpectations(t)
+	})
+}
+
+func TestPullRequestService_CreatePR_Skills(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 1}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("выбирается участник, чьи навыки покрывают теги PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockUserRepo.On("GetSkillsByTeamID", mock.Anything, 1).Return(map[string][]string{
+			"u2": {"go"},
+			"u3": {"go", "sql"},
+			"u4": {"frontend"},
+		}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add query",
+			AuthorID:      "u1",
+			Tags:          []string{"SQL", "go", "go"},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		assert.Equal(t, []string{"u3"}, createdPR.AssignedReviewers)
+		assert.Equal(t, []string{"go", "sql"}, createdPR.Tags)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("никто не подходит по навыкам: выбор среди всех кандидатов", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockUserRepo.On("GetSkillsByTeamID", mock.Anything, 1).Return(map[string][]string{"u2": {"go"}}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add mobile screen",
+			AuthorID:      "u1",
+			Tags:          []string{"ios"},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		require.Len(t, createdPR.AssignedReviewers, 1)
+		assert.Contains(t, []string{"u2", "u3", "u4"}, createdPR.AssignedReviewers[0])
+	})
+
+	t.Run("round_robin: навыков не хватает, курсор сдвигается один раз", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockRotationRepo := new(mocks.MockRotationRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRoundRobinSelector(mockRotationRepo), nil)
+
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").
+			Return(&domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockUserRepo.On("GetSkillsByTeamID", mock.Anything, 1).Return(map[string][]string{"u2": {"go"}}, nil).Once()
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("u2", nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add handler",
+			AuthorID:      "u1",
+			Tags:          []string{"go"},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		assert.Equal(t, []string{"u2", "u3"}, createdPR.AssignedReviewers, "сначала участник с навыком, затем следующий по кругу")
+		assert.Equal(t, "u3", mockRotationRepo.Cursor)
+		mockRotationRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: некорректный тег", func(t *testing.T) {
+		service := NewPullRequestService(nil, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		pr, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			Title:         "Add feature",
+			AuthorID:      "u1",
+			Tags:          []string{"go,sql"},
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, pr)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+	})
+}
+
+func TestPullRequestService_CreatePR_ReviewerCapacity(t *testing.T) {
+	limit := 2
+
+	t.Run("ревьюверы на пределе нагрузки пропускаются", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+		teamMembers := []*domain.User{
+			{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true},
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+			{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+		}
+
+		var createdPR *domain.PullRequest
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{"u2": 2, "u3": 1}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+			Run(func(args mock.Arguments) { createdPR = args.Get(1).(*domain.PullRequest) }).
+			Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+
+		require.NoError(t, err)
+		require.NotNil(t, createdPR)
+		assert.Equal(t, []string{"u3"}, createdPR.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: все кандидаты на пределе нагрузки", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+		teamMembers := []*domain.User{
+			{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true},
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{"u2": 2}, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil).Once()
+
+		pr, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+
+		require.Error(t, err)
+		assert.Nil(t, pr)
+		assert.True(t, errors.Is(err, domain.ErrNoCandidate))
+		assert.Contains(t, err.Error(), "review capacity")
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockPRRepo.AssertExpectations(t)
+	})
+}
+
+func TestPullRequestService_Transactions(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 1}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+	prColumns := []string{"id", "title", "author_id", "status", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}
+
+	// expectCreatePR1 ожидает в транзакции создание PR pr-1 автора u1 с ревьювером u2
+	expectCreatePR1 := func(mockDB sqlmock.Sqlmock) {
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM statuses").WithArgs("OPEN").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("INSERT INTO pull_requests").WithArgs(1, "Add feature", 1, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, time.Now(), nil))
+		mockDB.ExpectExec("SELECT setval").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockDB.ExpectExec("INSERT INTO pull_request_reviewers").WithArgs(1, 2, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	// setupCreatePR1 ожидает чтения, предшествующие созданию PR pr-1
+	setupCreatePR1 := func(mockPRRepo *mocks.MockPullRequestRepository, mockUserRepo *mocks.MockUserRepository, mockTeamRepo *mocks.MockTeamRepository) {
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers[:2], nil).Once()
+	}
+
+	t.Run("создание PR: PR и запись о выборе ревьюверов сохраняются в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(db, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		setupCreatePR1(mockPRRepo, mockUserRepo, mockTeamRepo)
+		expectCreatePR1(mockDB)
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").
+			WithArgs(1, "CREATE", sqlmock.AnyArg(), nil, []byte(`[]`), "", []byte(`[]`), []byte(`["u2"]`), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+		mockDB.ExpectCommit()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, result.AssignedReviewers)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка записи истории выбора откатывает создание PR", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(db, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		setupCreatePR1(mockPRRepo, mockUserRepo, mockTeamRepo)
+		expectCreatePR1(mockDB)
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").WillReturnError(errors.New("connection reset"))
+		mockDB.ExpectRollback()
+
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("переназначение: замена ревьювера и запись о выборе сохраняются в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(db, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(teamMembers[1], nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT EXISTS").WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mockDB.ExpectExec("UPDATE pull_request_reviewers SET reviewer_id").WithArgs(3, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(3, "backend"))
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").
+			WithArgs(1, "REASSIGN", sqlmock.AnyArg(), int64(2), []byte(`["u2"]`), "", []byte(`[]`), []byte(`["u3"]`), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+		mockDB.ExpectCommit()
+
+		result, newReviewerID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "u3", newReviewerID)
+		assert.Equal(t, []string{"u3"}, result.AssignedReviewers)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	// expectMergeChecks ожидает в транзакции блокировку и чтение OPEN PR pr-1 с ревьювером u2 в состоянии reviewState
+	// и загрузку политики merge команды автора, требующей одного одобрения
+	expectMergeChecks := func(mockDB sqlmock.Sqlmock, reviewState string) {
+		userColumns := []string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", reviewState))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, "Alice", 1, "backend", true, time.Now(), nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+				AddRow(1, "backend", 1, 2, time.Now(), nil))
+		mockDB.ExpectQuery("SELECT required_approvals, block_on_changes_requested").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"required_approvals", "block_on_changes_requested"}).AddRow(1, true))
+	}
+
+	t.Run("merge: проверка политики и смена статуса под блокировкой PR в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		expectMergeChecks(mockDB, "2:APPROVED")
+		mockDB.ExpectQuery("SELECT id FROM statuses").WithArgs("MERGED").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mockDB.ExpectQuery("UPDATE pull_requests").WithArgs(1, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "MERGED", time.Now(), time.Now(), nil, "", "", "2:APPROVED"))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectCommit()
+
+		result, err := service.MergePR(context.Background(), "pr-1", false, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, result.Status)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка смены статуса откатывает запись admin override", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		expectMergeChecks(mockDB, "")
+		mockDB.ExpectQuery("INSERT INTO pull_request_merge_overrides").
+			WithArgs(1, "hotfix", "0 of 1 required approvals", 0, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+		mockDB.ExpectQuery("SELECT id FROM statuses").WithArgs("MERGED").WillReturnError(errors.New("connection reset"))
+		mockDB.ExpectRollback()
+
+		result, err := service.MergePR(context.Background(), "pr-1", true, "hotfix")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("markReady: выбор, назначение и смена статуса под блокировкой черновика в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		userColumns := []string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "DRAFT", time.Now(), nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, "Alice", 1, "backend", true, time.Now(), nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+				AddRow(1, "backend", 1, 1, time.Now(), nil))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(1, "Alice", 1, "backend", true, time.Now(), nil, nil, 1.0, false).
+				AddRow(2, "Bob", 1, "backend", true, time.Now(), nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT user_id FROM team_mandatory_reviewers").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		mockDB.ExpectExec("INSERT INTO pull_request_reviewers").WithArgs(1, 2, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT id FROM statuses").WithArgs("OPEN").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("UPDATE pull_requests").WithArgs(1, 1, sqlmock.AnyArg(), nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").
+			WithArgs(1, "MARK_READY", sqlmock.AnyArg(), nil, []byte(`[]`), "", []byte(`[]`), []byte(`["u2"]`), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectCommit()
+
+		result, err := service.MarkReady(context.Background(), "pr-1", "", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, result.Status)
+		assert.Equal(t, []string{"u2"}, result.AssignedReviewers)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("markReady: черновик, уже переведенный в OPEN параллельным вызовом, не получает ревьюверов повторно", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectRollback()
+
+		result, err := service.MarkReady(context.Background(), "pr-1", "", nil)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrInvalidStatusTransition))
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("close: проверка перехода и смена статуса под блокировкой PR в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectQuery("SELECT id FROM statuses").WithArgs("CLOSED").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mockDB.ExpectQuery("UPDATE pull_requests").WithArgs(1, 4, sqlmock.AnyArg(), nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "CLOSED", time.Now(), nil, time.Now(), "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectCommit()
+
+		result, err := service.ClosePR(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, result.Status)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("close: PR, смерженный параллельным вызовом, не перезаписывается", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "MERGED", time.Now(), time.Now(), nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectRollback()
+
+		result, err := service.ClosePR(context.Background(), "pr-1")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrInvalidStatusTransition))
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	// expectLockedOpenPR1 ожидает в транзакции блокировку и чтение OPEN PR pr-1 автора u1 с ревьюверами reviewerIDs
+	// и загрузку команды автора с ограничениями min/max
+	expectLockedOpenPR1 := func(mockDB sqlmock.Sqlmock, minReviewers, maxReviewers int, reviewerIDs ...int) {
+		userColumns := []string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id FROM pull_requests WHERE id = \\$1 FOR UPDATE").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", ""))
+		reviewers := sqlmock.NewRows([]string{"reviewer_id", "name"})
+		for _, reviewerID := range reviewerIDs {
+			reviewers.AddRow(reviewerID, "backend")
+		}
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).WillReturnRows(reviewers)
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, "Alice", 1, "backend", true, time.Now(), nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+				AddRow(1, "backend", minReviewers, maxReviewers, time.Now(), nil))
+	}
+
+	t.Run("addReviewer: проверка лимита, добавление и запись в историю под блокировкой PR в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		userColumns := []string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		expectLockedOpenPR1(mockDB, 1, 2, 2)
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(3, "Charlie", 1, "backend", true, time.Now(), nil, nil, 1.0, false))
+		mockDB.ExpectExec("INSERT INTO pull_request_reviewers").WithArgs(1, 3, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").
+			WithArgs(1, "ADD_REVIEWER", int64(0), nil, []byte(`["u2"]`), "", []byte(`[]`), []byte(`["u3"]`), []byte(`[]`), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", time.Now(), nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend").AddRow(3, "backend"))
+		mockDB.ExpectCommit()
+
+		result, err := service.AddReviewer(context.Background(), "pr-1", "u3")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, result.AssignedReviewers)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("removeReviewer: ошибка записи истории откатывает снятие ревьювера", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+
+		service := NewPullRequestService(db, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		expectLockedOpenPR1(mockDB, 1, 3, 2, 3)
+		mockDB.ExpectQuery("SELECT user_id FROM team_mandatory_reviewers").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		mockDB.ExpectExec("DELETE FROM pull_request_reviewers").WithArgs(1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").WillReturnError(errors.New("connection reset"))
+		mockDB.ExpectRollback()
+
+		result, err := service.RemoveReviewer(context.Background(), "pr-1", "u3", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
+
+func TestPullRequestService_MergePR(t *testing.T) {
+	t.Run("успешный merge PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		openPR := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         time.Now(),
+			MergedAt:          nil,
+		}
+
+		mergedTime := time.Now()
+		mergedPR := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusMerged,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         openPR.CreatedAt,
+			MergedAt:          &mergedTime,
+		}
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, prID).Return(openPR, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", TeamName: "backend"}, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{}, nil).Once()
+		mockPRRepo.On("UpdateStatus", mock.Anything, prID, domain.StatusMerged, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(mergedPR, nil).Once()
+
+		result, err := service.MergePR(context.Background(), prID, false, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, result.Status)
+		assert.NotNil(t, result.MergedAt)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("идемпотентность: PR уже в статусе MERGED", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		mergedTime := time.Now()
+		mergedPR := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusMerged,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         time.Now(),
+			MergedAt:          &mergedTime,
+		}
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, prID).Return(mergedPR, nil).Once()
+
+		result, err := service.MergePR(context.Background(), prID, false, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, result.Status)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: PR не найден", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-999"
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
+
+		result, err := service.MergePR(context.Background(), prID, false, "")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 3}
+	policy := domain.MergePolicy{RequiredApprovals: 2, BlockOnChangesRequested: true}
+
+	t.Run("ошибка: недостаточно одобрений", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2", "u3"},
+			ReviewStates:      map[string]domain.ReviewState{"u2": domain.ReviewApproved, "u3": domain.ReviewCommented},
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(policy, nil).Once()
+
+		result, err := service.MergePR(context.Background(), "pr-1", false, "")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotApproved))
+		assert.Contains(t, err.Error(), "1 of 2 required approvals")
+		mockPRRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: ревьювер запросил изменения", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2", "u3", "u4"},
+			ReviewStates: map[string]domain.ReviewState{
+				"u2": domain.ReviewApproved,
+				"u3": domain.ReviewApproved,
+				"u4": domain.ReviewChangesRequested,
+			},
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(policy, nil).Once()
+
+		result, err := service.MergePR(context.Background(), "pr-1", false, "")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotApproved))
+		assert.Contains(t, err.Error(), "changes requested by u4")
+	})
+
+	t.Run("admin override записывает нарушение политики в журнал", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2", "u3"},
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(policy, nil).Once()
+		mockPRRepo.On("CreateMergeOverride", mock.Anything, mock.MatchedBy(func(o *domain.MergeOverride) bool {
+			return o.PullRequestID == "pr-1" && o.Reason == "hotfix" && o.Approvals == 0 && o.RequiredApprovals == 2 &&
+				o.Violation == "0 of 2 required approvals"
+		})).Return(nil).Once()
+		mockPRRepo.On("UpdateStatus", mock.Anything, "pr-1", domain.StatusMerged, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusMerged}, nil).Once()
+
+		result, err := service.MergePR(context.Background(), "pr-1", true, "hotfix")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, result.Status)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: admin override без причины", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		result, err := service.MergePR(context.Background(), "pr-1", true, " ")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockPRRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPullRequestService_Lifecycle(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("черновик создается без ревьюверов и истории выбора", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.Status == domain.StatusDraft && len(pr.AssignedReviewers) == 0
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusDraft, AssignedReviewers: []string{}}, nil).Once()
+
+		result, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "WIP", AuthorID: "u1", Draft: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusDraft, result.Status)
+		assert.Empty(t, result.AssignedReviewers)
+		mockPRRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+		mockTeamRepo.AssertNotCalled(t, "GetByName", mock.Anything, mock.Anything)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: CODEOWNERS черновика передаются в markReady", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		result, err := service.CreatePR(context.Background(), CreatePRInput{
+			PullRequestID: "pr-1",
+			AuthorID:      "u1",
+			Draft:         true,
+			Repository:    "backend",
+			ChangedFiles:  []string{"main.go"},
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("markReady назначает ревьюверов и переводит черновик в OPEN", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusDraft, AssignedReviewers: []string{}}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockPRRepo.On("AddReviewer", mock.Anything, "pr-1", mock.AnythingOfType("string")).Return(nil).Twice()
+		mockPRRepo.On("UpdateStatus", mock.Anything, "pr-1", domain.StatusOpen, (*time.Time)(nil)).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return a.Event == domain.AssignmentMarkReady && len(a.SelectedReviewers) == 2
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}, nil).Once()
+
+		result, err := service.MarkReady(context.Background(), "pr-1", "", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, result.Status)
+		assert.Len(t, result.AssignedReviewers, 2)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: markReady для OPEN PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		result, err := service.MarkReady(context.Background(), "pr-1", "", nil)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrInvalidStatusTransition))
+		mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("OPEN PR закрывается и открывается заново", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockPRRepo.On("UpdateStatus", mock.Anything, "pr-1", domain.StatusClosed, (*time.Time)(nil)).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusClosed, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusClosed, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockPRRepo.On("UpdateStatus", mock.Anything, "pr-1", domain.StatusOpen, (*time.Time)(nil)).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		closed, err := service.ClosePR(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, closed.Status)
+
+		reopened, err := service.ReopenPR(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, reopened.Status)
+		assert.Equal(t, []string{"u2"}, reopened.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: MERGED PR нельзя закрыть", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusMerged}, nil).Once()
+
+		result, err := service.ClosePR(context.Background(), "pr-1")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrInvalidStatusTransition))
+		mockPRRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: черновик нельзя смержить", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusDraft}, nil).Once()
+
+		result, err := service.MergePR(context.Background(), "pr-1", true, "hotfix")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrInvalidStatusTransition))
+	})
+
+	t.Run("ошибка: ревьювера нельзя добавить на закрытый PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusClosed, AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		result, err := service.AddReviewer(context.Background(), "pr-1", "u3")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrPRNotOpen))
+	})
+}
+
+func TestPullRequestService_ReassignReviewer(t *testing.T) {
+	t.Run("успешное переназначение ревьювера", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		oldReviewerID := "u2"
+		newReviewerID := "u3"
+
+		pr := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{oldReviewerID, "u4"},
+			CreatedAt:         time.Now(),
+			MergedAt:          nil,
+		}
+
+		oldReviewer := &domain.User{
+			ID:        oldReviewerID,
+			Username:  "Bob",
+			TeamID:    1,
+			TeamName:  "backend",
+			IsActive:  true,
+			CreatedAt: time.Now(),
+		}
+
+		team := &domain.Team{
+			ID:           1,
+			Name:         "backend",
+			MinReviewers: 1,
+			MaxReviewers: 2,
+			Members: []domain.TeamMember{
+				{UserID: "u2", Username: "Bob", IsActive: true},
+				{UserID: "u3", Username: "Charlie", IsActive: true},
+				{UserID: "u5", Username: "Dave", IsActive: true},
+			},
+			CreatedAt: time.Now(),
+		}
+
+		teamMembers := []*domain.User{
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+			{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+			{ID: "u5", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
+		}
+
+		updatedPR := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{newReviewerID, "u4"},
+			CreatedAt:         pr.CreatedAt,
+			MergedAt:          nil,
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", TeamID: 1, TeamName: "backend"}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, oldReviewerID).Return(oldReviewer, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, prID, oldReviewerID, mock.AnythingOfType("string")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(updatedPR, nil).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, prID, result.ID)
+		assert.NotEmpty(t, newReviewer)
+		assert.NotEqual(t, oldReviewerID, newReviewer)
+		assert.NotContains(t, result.AssignedReviewers, oldReviewerID)
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("добор ревьюверов до min_reviewers после замены", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		pr := &domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}
+		oldReviewer := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "platform", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "platform", MinReviewers: 2, MaxReviewers: 3}
+		teamMembers := []*domain.User{
+			{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "platform", IsActive: true},
+			{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "platform", IsActive: true},
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", TeamID: 1, TeamName: "backend"}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(oldReviewer, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u2", mock.AnythingOfType("string")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u3"},
+		}, nil).Once()
+		mockPRRepo.On("AddReviewer", mock.Anything, "pr-1", "u4").Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return a.Event == domain.AssignmentReassign && a.ReplacedReviewerID == "u2" &&
+				assert.ObjectsAreEqual([]string{"u2"}, a.PreviousReviewers) && len(a.SelectedReviewers) == 2
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u3", "u4"},
+		}, nil).Once()
+
+		result, _, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u4"}, result.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: PR не найден", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-999"
+
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(nil, errors.New("pull request not found")).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, "u2", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newReviewer)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: PR уже в статусе MERGED", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		mergedTime := time.Now()
+		mergedPR := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusMerged,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         time.Now(),
+			MergedAt:          &mergedTime,
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(mergedPR, nil).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, "u2", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newReviewer)
+		assert.True(t, errors.Is(err, domain.ErrPRMerged))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: ревьювер не назначен на PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		pr := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         time.Now(),
+			MergedAt:          nil,
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, "u999", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newReviewer)
+		assert.True(t, errors.Is(err, domain.ErrNotAssigned))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: нет доступных кандидатов для замены", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		oldReviewerID := "u2"
+
+		pr := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{oldReviewerID},
+			CreatedAt:         time.Now(),
+			MergedAt:          nil,
+		}
+
+		oldReviewer := &domain.User{
+			ID:        oldReviewerID,
+			Username:  "Bob",
+			TeamID:    1,
+			TeamName:  "backend",
+			IsActive:  true,
+			CreatedAt: time.Now(),
+		}
+
+		team := &domain.Team{
+			ID:           1,
+			Name:         "backend",
+			MinReviewers: 1,
+			MaxReviewers: 2,
+			Members: []domain.TeamMember{
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			},
+			CreatedAt: time.Now(),
+		}
+
+		teamMembers := []*domain.User{
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", TeamID: 1, TeamName: "backend"}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, oldReviewerID).Return(oldReviewer, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID, false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newReviewer)
+		assert.True(t, errors.Is(err, domain.ErrNoCandidate))
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: старый ревьювер не найден", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		prID := "pr-1"
+		oldReviewerID := "u999"
+
+		pr := &domain.PullRequest{
+			ID:                prID,
+			Title:             "Add feature",
+			AuthorID:          "u1",
+			Status:            domain.StatusOpen,
+			AssignedReviewers: []string{oldReviewerID},
+			CreatedAt:         time.Now(),
+			MergedAt:          nil,
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", TeamID: 1, TeamName: "backend"}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, oldReviewerID).Return(nil, errors.New("user not found")).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID, false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newReviewer)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestPullRequestService_ReassignReviewer_MandatoryReviewer(t *testing.T) {
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u7", "u2"}}
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+
+	t.Run("ошибка: обязательного ревьювера нельзя заменить без force", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{"u7"}, nil).Once()
+
+		result, newReviewer, err := service.ReassignReviewer(context.Background(), "pr-1", "u7", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newReviewer)
+		assert.True(t, errors.Is(err, domain.ErrMandatoryReviewer))
+		mockPRRepo.AssertNotCalled(t, "ReplaceReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("с force обязательный ревьювер заменяется", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		champion := &domain.User{ID: "u7", Username: "Grace", TeamID: 3, TeamName: "security", IsActive: true}
+		securityTeam := &domain.Team{ID: 3, Name: "security", MinReviewers: 1, MaxReviewers: 2}
+		securityMembers := []*domain.User{
+			champion,
+			{ID: "u8", Username: "Heidi", TeamID: 3, TeamName: "security", IsActive: true},
+		}
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u7").Return(champion, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "security").Return(securityTeam, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 3).Return(securityMembers, nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u7", "u8").Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u8", "u2"}}, nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+
+		_, newReviewer, err := service.ReassignReviewer(context.Background(), "pr-1", "u7", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, "u8", newReviewer)
+		mockTeamRepo.AssertNotCalled(t, "GetMandatoryReviewers", mock.Anything, mock.Anything)
+		mockPRRepo.AssertExpectations(t)
+	})
+}
+
+func TestPullRequestService_ReassignReviewerTo(t *testing.T) {
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	oldReviewer := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		author,
+		oldReviewer,
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("ревьювер заменяется на явно выбранного участника команды", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(oldReviewer, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u4").Return(teamMembers[3], nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u2", "u4").Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u4", "u3"}}, nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return a.Event == domain.AssignmentManualReassign && a.ReplacedReviewerID == "u2" &&
+				assert.ObjectsAreEqual([]string{"u4"}, a.SelectedReviewers)
+		})).Return(nil).Once()
+
+		result, err := service.ReassignReviewerTo(context.Background(), "pr-1", "u2", "u4", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u3"}, result.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("можно выбрать участника резервной команды", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		platformUser := &domain.User{ID: "u9", Username: "Ivan", TeamID: 2, TeamName: "platform", IsActive: true}
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(oldReviewer, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u9").Return(platformUser, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{{ID: 2, Name: "platform"}}, nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u2", "u9").Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u9", "u3"}}, nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+
+		result, err := service.ReassignReviewerTo(context.Background(), "pr-1", "u2", "u9", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u9", "u3"}, result.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name          string
+		newReviewerID string
+		newReviewer   *domain.User
+		fallbackTeams []*domain.Team
+		expected      error
+	}{
+		{
+			name:          "ошибка: новый ревьювер - автор PR",
+			newReviewerID: "u1",
+			expected:      domain.ErrInvalidReviewer,
+		},
+		{
+			name:          "ошибка: новый ревьювер уже назначен",
+			newReviewerID: "u3",
+			expected:      domain.ErrInvalidReviewer,
+		},
+		{
+			name:          "ошибка: новый ревьювер неактивен",
+			newReviewerID: "u5",
+			newReviewer:   &domain.User{ID: "u5", TeamID: 1, TeamName: "backend", IsActive: false},
+			expected:      domain.ErrInvalidReviewer,
+		},
+		{
+			name:          "ошибка: новый ревьювер отсутствует",
+			newReviewerID: "u5",
+			newReviewer:   &domain.User{ID: "u5", TeamID: 1, TeamName: "backend", IsActive: true, Unavailable: true},
+			expected:      domain.ErrInvalidReviewer,
+		},
+		{
+			name:          "ошибка: новый ревьювер из команды, не являющейся резервной",
+			newReviewerID: "u9",
+			newReviewer:   &domain.User{ID: "u9", TeamID: 5, TeamName: "mobile", IsActive: true},
+			fallbackTeams: []*domain.Team{{ID: 2, Name: "platform"}},
+			expected:      domain.ErrInvalidReviewer,
+		},
+		{
+			name:          "ошибка: новый ревьювер не найден",
+			newReviewerID: "u999",
+			expected:      domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPRRepo := new(mocks.MockPullRequestRepository)
+			mockUserRepo := new(mocks.MockUserRepository)
+			mockTeamRepo := new(mocks.MockTeamRepository)
+
+			service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+			mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+			mockUserRepo.On("GetByID", mock.Anything, "u2").Return(oldReviewer, nil).Once()
+			mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+			mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+			if tt.newReviewer != nil {
+				mockUserRepo.On("GetByID", mock.Anything, tt.newReviewerID).Return(tt.newReviewer, nil).Once()
+			} else {
+				mockUserRepo.On("GetByID", mock.Anything, tt.newReviewerID).Return(nil, errors.New("user not found")).Maybe()
+			}
+			if tt.fallbackTeams != nil {
+				mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return(tt.fallbackTeams, nil).Once()
+			}
+
+			result, err := service.ReassignReviewerTo(context.Background(), "pr-1", "u2", tt.newReviewerID, true)
+
+			require.Error(t, err)
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, tt.expected))
+			mockPRRepo.AssertNotCalled(t, "ReplaceReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPullRequestService_AddReviewer(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+
+	t.Run("ревьювер добавляется и изменение записывается в историю", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u3").
+			Return(&domain.User{ID: "u3", TeamID: 1, TeamName: "backend", IsActive: true}, nil).Once()
+		mockPRRepo.On("AddReviewer", mock.Anything, "pr-1", "u3").Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return a.Event == domain.AssignmentAddReviewer &&
+				assert.ObjectsAreEqual([]string{"u2"}, a.PreviousReviewers) &&
+				assert.ObjectsAreEqual([]string{"u3"}, a.SelectedReviewers)
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}, nil).Once()
+
+		result, err := service.AddReviewer(context.Background(), "pr-1", "u3")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, result.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: на PR уже max_reviewers ревьюверов", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+
+		result, err := service.AddReviewer(context.Background(), "pr-1", "u4")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrReviewerLimit))
+		mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: автор не может быть ревьювером своего PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+
+		result, err := service.AddReviewer(context.Background(), "pr-1", "u1")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrInvalidReviewer))
+		mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: PR уже в статусе MERGED", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusMerged, AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		result, err := service.AddReviewer(context.Background(), "pr-1", "u3")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrPRMerged))
+	})
+}
+
+func TestPullRequestService_RemoveReviewer(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 3}
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+	t.Run("ревьювер снимается и изменение записывается в историю", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockPRRepo.On("RemoveReviewer", mock.Anything, "pr-1", "u3").Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return a.Event == domain.AssignmentRemoveReviewer && a.ReplacedReviewerID == "u3" && len(a.SelectedReviewers) == 0
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		result, err := service.RemoveReviewer(context.Background(), "pr-1", "u3", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, result.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: ревьюверов станет меньше min_reviewers", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+
+		result, err := service.RemoveReviewer(context.Background(), "pr-1", "u2", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrReviewerLimit))
+		mockPRRepo.AssertNotCalled(t, "RemoveReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: обязательного ревьювера нельзя снять без force", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{"u3"}, nil).Once()
+
+		result, err := service.RemoveReviewer(context.Background(), "pr-1", "u3", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrMandatoryReviewer))
+		mockPRRepo.AssertNotCalled(t, "RemoveReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: ревьювер не назначен на PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").Return(pr, nil).Once()
+
+		result, err := service.RemoveReviewer(context.Background(), "pr-1", "u9", false)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotAssigned))
+	})
+
+	t.Run("ошибка: PR уже в статусе MERGED", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByIDForUpdate", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusMerged, AssignedReviewers: []string{"u2", "u3"}}, nil).Once()
+
+		result, err := service.RemoveReviewer(context.Background(), "pr-1", "u3", true)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrPRMerged))
+	})
+}
+
+func TestPullRequestService_SubmitReview(t *testing.T) {
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+	t.Run("решение ревьювера сохраняется", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockPRRepo.On("CreateReview", mock.Anything, mock.MatchedBy(func(r *domain.Review) bool {
+			return r.PullRequestID == "pr-1" && r.ReviewerID == "u2" && r.State == domain.ReviewApproved
+		})).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorID:          "u1",
+				Status:            domain.StatusOpen,
+				AssignedReviewers: []string{"u2", "u3"},
+				ReviewStates:      map[string]domain.ReviewState{"u2": domain.ReviewApproved},
+			}, nil).Once()
+
+		result, err := service.SubmitReview(context.Background(), "pr-1", "u2", domain.ReviewApproved)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReviewApproved, result.ReviewStates["u2"])
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: недопустимое решение", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		result, err := service.SubmitReview(context.Background(), "pr-1", "u2", domain.ReviewPending)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockPRRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: пользователь не назначен ревьювером", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+
+		result, err := service.SubmitReview(context.Background(), "pr-1", "u9", domain.ReviewCommented)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotAssigned))
+		mockPRRepo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: PR уже в статусе MERGED", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusMerged, AssignedReviewers: []string{"u2"}}, nil).Once()
+
+		result, err := service.SubmitReview(context.Background(), "pr-1", "u2", domain.ReviewChangesRequested)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrPRMerged))
+	})
+}
+
+func TestPullRequestService_ExplainAssignment(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u5", Username: "Eve", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("выбор при создании PR воспроизводится по сохраненному seed", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), NewAssignmentSeeder("secret"))
+
+		var assignment *domain.Assignment
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil)
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil)
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).
+			Run(func(args mock.Arguments) { assignment = args.Get(1).(*domain.Assignment) }).
+			Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, Tags: []string{}}, nil)
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+		require.NoError(t, err)
+		require.NotNil(t, assignment)
+		assert.Equal(t, NewAssignmentSeeder("secret").Seed("pr-1", createSeedKey()...), assignment.Seed)
+
+		mockPRRepo.On("GetAssignments", mock.Anything, "pr-1").Return([]*domain.Assignment{assignment}, nil).Once()
+
+		replays, err := service.ExplainAssignment(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		assert.Equal(t, assignment.SelectedReviewers, replays[0].ReplayedReviewers)
+		assert.True(t, replays[0].Reproduced)
+	})
+
+	t.Run("замена ревьювера воспроизводится, изменившийся выбор отмечается", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), NewAssignmentSeeder("secret"))
+
+		pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(teamMembers[0], nil)
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(teamMembers[1], nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil)
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil)
+
+		var assignment *domain.Assignment
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u2", mock.AnythingOfType("string")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).
+			Run(func(args mock.Arguments) { assignment = args.Get(1).(*domain.Assignment) }).
+			Return(nil).Once()
+
+		_, newReviewerID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2", false)
+		require.NoError(t, err)
+		require.NotNil(t, assignment)
+		assert.Equal(t, []string{newReviewerID}, assignment.SelectedReviewers)
+
+		changed := *assignment
+		changed.SelectedReviewers = []string{"u1"}
+		mockPRRepo.On("GetAssignments", mock.Anything, "pr-1").Return([]*domain.Assignment{assignment, &changed}, nil).Once()
+
+		replays, err := service.ExplainAssignment(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, replays, 2)
+		assert.Equal(t, []string{newReviewerID}, replays[0].ReplayedReviewers)
+		assert.True(t, replays[0].Reproduced)
+		assert.False(t, replays[1].Reproduced)
+	})
+
+	t.Run("явная замена воспроизводится с сохраненным новым ревьювером", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), NewAssignmentSeeder("secret"))
+
+		pr := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u5", "u3"}}
+		assignment := &domain.Assignment{
+			PullRequestID:      "pr-1",
+			Event:              domain.AssignmentManualReassign,
+			ReplacedReviewerID: "u2",
+			PreviousReviewers:  []string{"u2", "u3"},
+			SelectedReviewers:  []string{"u5"},
 		}
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockPRRepo.On("GetAssignments", mock.Anything, "pr-1").Return([]*domain.Assignment{assignment}, nil).Once()
 
-		mockPRRepo.On("GetByID", mock.Anything, prID).Return(pr, nil).Once()
-		mockUserRepo.On("GetByID", mock.Anything, oldReviewerID).Return(nil, errors.New("user not found")).Once()
+		replays, err := service.ExplainAssignment(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		assert.Equal(t, []string{"u5"}, replays[0].ReplayedReviewers)
+		assert.True(t, replays[0].Reproduced)
+		mockUserRepo.AssertNotCalled(t, "GetByTeamID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("least_loaded воспроизводится по сохраненной нагрузке и истории ревью", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		selector := NewAntiRepetitionSelector(NewLeastLoadedSelector(mockPRRepo), mockPRRepo, 5)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, selector, NewAssignmentSeeder("secret"))
+
+		var assignment *domain.Assignment
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil)
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil)
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).
+			Return(map[string]int{"u2": 3, "u4": 1}, nil).Once()
+		mockPRRepo.On("GetRecentReviewCounts", mock.Anything, "u1", 5).Return(map[string]int{"u3": 2}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).
+			Run(func(args mock.Arguments) { assignment = args.Get(1).(*domain.Assignment) }).
+			Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, Tags: []string{}}, nil)
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+		require.NoError(t, err)
+		require.NotNil(t, assignment)
+		assert.Equal(t, []string{"u5", "u3"}, assignment.SelectedReviewers, "при равной нагрузке раньше тот, кто реже ревьюил автора")
+
+		// Назначение само увеличило нагрузку выбранных и попало в историю ревью автора
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).
+			Return(map[string]int{"u2": 3, "u3": 1, "u4": 1, "u5": 1}, nil)
+		mockPRRepo.On("GetRecentReviewCounts", mock.Anything, "u1", 5).Return(map[string]int{"u3": 3, "u5": 1}, nil)
+		mockPRRepo.On("GetAssignments", mock.Anything, "pr-1").Return([]*domain.Assignment{assignment}, nil).Once()
+
+		replays, err := service.ExplainAssignment(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		assert.Equal(t, []string{"u5", "u3"}, replays[0].ReplayedReviewers)
+		assert.True(t, replays[0].Reproduced)
+		mockPRRepo.AssertNumberOfCalls(t, "GetOpenReviewCountsByTeamID", 1)
+		mockPRRepo.AssertNumberOfCalls(t, "GetRecentReviewCounts", 1)
+	})
+
+	t.Run("round_robin воспроизводится по курсору на момент назначения", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockRotationRepo := new(mocks.MockRotationRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRoundRobinSelector(mockRotationRepo), NewAssignmentSeeder("secret"))
+
+		var assignment *domain.Assignment
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil)
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil)
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("u2", nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).
+			Run(func(args mock.Arguments) { assignment = args.Get(1).(*domain.Assignment) }).
+			Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, Tags: []string{}}, nil)
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+		require.NoError(t, err)
+		require.NotNil(t, assignment)
+		assert.Equal(t, []string{"u3", "u4"}, assignment.SelectedReviewers)
+		assert.Equal(t, "u4", mockRotationRepo.Cursor)
+
+		// С текущего курсора u4 выбор дал бы u5 и u2
+		mockRotationRepo.On("GetCursor", mock.Anything, 1).Return("u4", nil)
+		mockPRRepo.On("GetAssignments", mock.Anything, "pr-1").Return([]*domain.Assignment{assignment}, nil).Once()
+
+		replays, err := service.ExplainAssignment(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		assert.Equal(t, []string{"u3", "u4"}, replays[0].ReplayedReviewers)
+		assert.True(t, replays[0].Reproduced)
+		mockRotationRepo.AssertNotCalled(t, "GetCursor", mock.Anything, mock.Anything)
+		mockRotationRepo.AssertNumberOfCalls(t, "AdvanceCursor", 1)
+	})
+
+	t.Run("запись без сохраненных входных данных не воспроизводится", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		assignment := &domain.Assignment{
+			PullRequestID:     "pr-1",
+			Event:             domain.AssignmentCreate,
+			SelectedReviewers: []string{"u2"},
+			Steps: []*domain.SelectionStep{{
+				Source:     domain.SourceTeam,
+				TeamName:   "backend",
+				Strategy:   StrategyRandom,
+				Candidates: []string{"u2"},
+				Excluded:   []*domain.ExcludedCandidate{},
+				Selected:   []string{"u2"},
+			}},
+		}
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1"}, nil).Once()
+		mockPRRepo.On("GetAssignments", mock.Anything, "pr-1").Return([]*domain.Assignment{assignment}, nil).Once()
+
+		replays, err := service.ExplainAssignment(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		assert.Empty(t, replays[0].ReplayedReviewers)
+		assert.False(t, replays[0].Reproduced)
+	})
+
+	t.Run("ошибка: PR не найден", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-999").Return(nil, errors.New("pull request not found")).Once()
+
+		replays, err := service.ExplainAssignment(context.Background(), "pr-999")
+
+		require.Error(t, err)
+		assert.Nil(t, replays)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+}
+
+func TestPullRequestService_AssignmentTrace(t *testing.T) {
+	limit := 1
+
+	t.Run("при создании PR записываются кандидаты, исключенные и стратегия", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+		team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+		teamMembers := []*domain.User{
+			author,
+			{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: false},
+			{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true, Unavailable: true},
+			{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+			{ID: "u5", Username: "Eve", TeamID: 1, TeamName: "backend", IsActive: true},
+		}
+
+		var assignment *domain.Assignment
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, errors.New("pull request not found")).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{"u4": 1}, nil).Once()
+		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).
+			Run(func(args mock.Arguments) { assignment = args.Get(1).(*domain.Assignment) }).
+			Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil).Once()
+
+		_, err := service.CreatePR(context.Background(), CreatePRInput{PullRequestID: "pr-1", Title: "Add feature", AuthorID: "u1"})
+
+		require.NoError(t, err)
+		require.NotNil(t, assignment)
+		assert.Equal(t, []string{"u5"}, assignment.SelectedReviewers)
+		assert.Equal(t, []*domain.SelectionStep{{
+			Source:     domain.SourceTeam,
+			TeamName:   "backend",
+			Strategy:   StrategyRandom,
+			Candidates: []string{"u5"},
+			Excluded: []*domain.ExcludedCandidate{
+				{UserID: "u1", Reason: domain.ExclusionAuthor},
+				{UserID: "u2", Reason: domain.ExclusionInactive},
+				{UserID: "u3", Reason: domain.ExclusionUnavailable},
+				{UserID: "u4", Reason: domain.ExclusionAtCapacity},
+			},
+			Selected: []string{"u5"},
+			Inputs: &domain.SelectionInputs{
+				Pool:         []string{"u1", "u2", "u3", "u5"},
+				MaxReviewers: 2,
+				Weights:      map[string]float64{"u5": 1},
+			},
+		}}, assignment.Steps)
+	})
+
+	t.Run("ошибка: PR не найден", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-999").Return(nil, errors.New("pull request not found")).Once()
+
+		assignments, err := service.GetAssignments(context.Background(), "pr-999")
+
+		require.Error(t, err)
+		assert.Nil(t, assignments)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockPRRepo.AssertNotCalled(t, "GetAssignments", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPullRequestService_SimulateSelection(t *testing.T) {
+	author := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 1}
+	teamMembers := []*domain.User{
+		author,
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: false},
+	}
+
+	t.Run("распределение выборов для автора без записи в БД", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
 
-		result, newReviewer, err := service.ReassignReviewer(context.Background(), prID, oldReviewerID)
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Times(200)
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{AuthorID: "u1", Iterations: 200})
+
+		require.NoError(t, err)
+		assert.Equal(t, "backend", result.TeamName)
+		assert.Equal(t, StrategyRandom, result.Strategy)
+		assert.Equal(t, 200, result.Iterations)
+		assert.Equal(t, 0, result.NoCandidate)
+		require.Len(t, result.Picks, 2, "автор и неактивный участник не выбираются")
+		total := 0
+		for _, pick := range result.Picks {
+			assert.Contains(t, []string{"u2", "u3"}, pick.UserID)
+			total += pick.Count
+		}
+		assert.Equal(t, 200, total)
+		assert.GreaterOrEqual(t, result.Picks[0].Count, result.Picks[1].Count)
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockPRRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("round_robin по команде: курсор сдвигается между повторами только в памяти", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockRotationRepo := new(mocks.MockRotationRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRoundRobinSelector(mockRotationRepo), nil)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockRotationRepo.On("GetCursor", mock.Anything, 1).Return("u2", nil).Once()
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{TeamName: "backend", Iterations: 9})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.AuthorID)
+		assert.Equal(t, StrategyRoundRobin, result.Strategy)
+		assert.Equal(t, []*domain.ReviewerPicks{
+			{UserID: "u1", Count: 3},
+			{UserID: "u2", Count: 3},
+			{UserID: "u3", Count: 3},
+		}, result.Picks, "выборы распределяются по всем активным участникам команды")
+		mockRotationRepo.AssertExpectations(t)
+		mockRotationRepo.AssertNotCalled(t, "AdvanceCursor", mock.Anything, mock.Anything)
+	})
+
+	t.Run("least_loaded: выборы предыдущих повторов прибавляются к нагрузке", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewLeastLoadedSelector(mockPRRepo), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{"u2": 0, "u3": 2}, nil)
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{AuthorID: "u1", Iterations: 4})
+
+		require.NoError(t, err)
+		assert.Equal(t, StrategyLeastLoaded, result.Strategy)
+		// u2 выбирается, пока его нагрузка с учетом повторов не сравняется с u3, затем они чередуются
+		assert.Equal(t, []*domain.ReviewerPicks{
+			{UserID: "u2", Count: 3},
+			{UserID: "u3", Count: 1},
+		}, result.Picks)
+	})
+
+	t.Run("повторы без кандидатов учитываются в NoCandidate", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(author, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers[:1], nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil)
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil)
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{AuthorID: "u1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, defaultSimulationIterations, result.Iterations)
+		assert.Equal(t, defaultSimulationIterations, result.NoCandidate)
+		assert.Empty(t, result.Picks)
+	})
+
+	t.Run("ошибка: не задан ни автор, ни команда", func(t *testing.T) {
+		service := NewPullRequestService(nil, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+	})
+
+	t.Run("ошибка: слишком много повторов", func(t *testing.T) {
+		service := NewPullRequestService(nil, nil, nil, nil, nil, NewRandomSelector(), nil)
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{AuthorID: "u1", Iterations: maxSimulationIterations + 1})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+	})
+
+	t.Run("ошибка: автор не найден", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewPullRequestService(nil, nil, mockUserRepo, nil, nil, NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.SimulateSelection(context.Background(), SimulationInput{AuthorID: "u999"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+}
+
+func TestPullRequestService_GetPR(t *testing.T) {
+	t.Run("успешное получение PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen}, nil).Once()
+
+		result, err := service.GetPR(context.Background(), "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "pr-1", result.ID)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: невалидный ID PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("GetByID", mock.Anything, "invalid").Return(nil, errors.New("invalid pull request ID")).Once()
+
+		result, err := service.GetPR(context.Background(), "invalid")
 
 		require.Error(t, err)
 		assert.Nil(t, result)
-		assert.Empty(t, newReviewer)
 		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+}
+
+func TestPullRequestService_ListPRs(t *testing.T) {
+	createdAt := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	prs := []*domain.PullRequest{
+		{ID: "pr-3", CreatedAt: createdAt.Add(2 * time.Hour)},
+		{ID: "pr-2", CreatedAt: createdAt.Add(time.Hour)},
+		{ID: "pr-1", CreatedAt: createdAt},
+	}
+
+	t.Run("курсор следующей страницы продолжает выборку", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.PullRequestFilter) bool {
+			return f.After == nil && f.Limit == 3 && f.Status == domain.StatusOpen && !f.Ascending
+		})).Return(prs, nil).Once()
+
+		page, err := service.ListPRs(context.Background(), ListPRsInput{Status: domain.StatusOpen, Limit: 2})
+
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 2)
+		assert.Equal(t, "pr-2", page.PullRequests[1].ID)
+		require.NotEmpty(t, page.NextCursor)
+
+		mockPRRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.PullRequestFilter) bool {
+			return f.After != nil && f.After.PullRequestID == "pr-2" && f.After.CreatedAt.Equal(prs[1].CreatedAt)
+		})).Return(prs[2:], nil).Once()
+
+		page, err = service.ListPRs(context.Background(), ListPRsInput{Status: domain.StatusOpen, Limit: 2, Cursor: page.NextCursor})
+
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 1)
+		assert.Equal(t, "pr-1", page.PullRequests[0].ID)
+		assert.Empty(t, page.NextCursor)
 		mockPRRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("лимит по умолчанию", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.PullRequestFilter) bool {
+			return f.Limit == defaultListLimit+1 && f.Ascending
+		})).Return([]*domain.PullRequest{}, nil).Once()
+
+		page, err := service.ListPRs(context.Background(), ListPRsInput{Order: "asc"})
+
+		require.NoError(t, err)
+		assert.Empty(t, page.PullRequests)
+		assert.Empty(t, page.NextCursor)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("курсор сохраняет время создания без пересчета часового пояса", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		msk := time.FixedZone("MSK", 3*60*60)
+		page1 := []*domain.PullRequest{
+			{ID: "pr-2", CreatedAt: time.Date(2024, 7, 1, 12, 0, 0, 123456000, msk)},
+			{ID: "pr-1", CreatedAt: time.Date(2024, 7, 1, 11, 0, 0, 0, msk)},
+		}
+		mockPRRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.PullRequestFilter) bool {
+			return f.After == nil
+		})).Return(page1, nil).Once()
+
+		page, err := service.ListPRs(context.Background(), ListPRsInput{Limit: 1})
+		require.NoError(t, err)
+		require.NotEmpty(t, page.NextCursor)
+
+		mockPRRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.PullRequestFilter) bool {
+			return f.After != nil && f.After.CreatedAt.Format("2006-01-02 15:04:05.999999") == "2024-07-01 12:00:00.123456"
+		})).Return([]*domain.PullRequest{}, nil).Once()
+
+		_, err = service.ListPRs(context.Background(), ListPRsInput{Limit: 1, Cursor: page.NextCursor})
+
+		require.NoError(t, err)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	from := createdAt
+	to := createdAt.Add(-time.Hour)
+	invalidInputs := []struct {
+		name  string
+		input ListPRsInput
+	}{
+		{"ошибка: неизвестный статус", ListPRsInput{Status: "UNKNOWN"}},
+		{"ошибка: неизвестный порядок сортировки", ListPRsInput{Order: "newest"}},
+		{"ошибка: начало периода не раньше конца", ListPRsInput{CreatedFrom: &from, CreatedTo: &to}},
+		{"ошибка: лимит больше максимального", ListPRsInput{Limit: maxListLimit + 1}},
+		{"ошибка: некорректный курсор", ListPRsInput{Cursor: "not-a-cursor"}},
+	}
+	for _, tt := range invalidInputs {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPRRepo := new(mocks.MockPullRequestRepository)
+
+			service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+			page, err := service.ListPRs(context.Background(), tt.input)
+
+			require.Error(t, err)
+			assert.Nil(t, page)
+			assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+			mockPRRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("ошибка: невалидный ID автора", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, nil, nil, nil, NewRandomSelector(), nil)
+
+		mockPRRepo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("invalid author ID")).Once()
+
+		page, err := service.ListPRs(context.Background(), ListPRsInput{AuthorID: "alice"})
+
+		require.Error(t, err)
+		assert.Nil(t, page)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
 	})
 }
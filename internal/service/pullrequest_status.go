@@ -0,0 +1,57 @@
+package service
+
+import "github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+
+// statusAction - действие, меняющее статус PR
+type statusAction string
+
+const (
+	actionMarkReady statusAction = "mark ready"
+	actionMerge     statusAction = "merge"
+	actionClose     statusAction = "close"
+	actionReopen    statusAction = "reopen"
+)
+
+// statusTransitions - машина состояний PR: для каждого статуса - допустимые в нем действия и статус после действия.
+// MERGED - конечный статус.
+var statusTransitions = map[domain.Status]map[statusAction]domain.Status{
+	domain.StatusDraft: {
+		actionMarkReady: domain.StatusOpen,
+		actionClose:     domain.StatusClosed,
+	},
+	domain.StatusOpen: {
+		actionMerge: domain.StatusMerged,
+		actionClose: domain.StatusClosed,
+	},
+	domain.StatusClosed: {
+		actionReopen: domain.StatusOpen,
+	},
+}
+
+// nextStatus возвращает статус PR после действия action или INVALID_STATUS_TRANSITION, если действие недопустимо.
+// Закрытый PR без ревьюверов открывается заново в DRAFT, чтобы ревьюверы были назначены при markReady.
+func nextStatus(pr *domain.PullRequest, action statusAction) (domain.Status, error) {
+	next, ok := statusTransitions[pr.Status][action]
+	if !ok {
+		return "", domain.NewInvalidStatusTransitionError(pr.Status, string(action))
+	}
+
+	if action == actionReopen && len(pr.AssignedReviewers) == 0 {
+		return domain.StatusDraft, nil
+	}
+
+	return next, nil
+}
+
+// requireOpen проверяет, что ревьюверов и решения по PR можно изменять.
+// Для MERGED возвращается PR_MERGED, для остальных статусов, кроме OPEN, - PR_NOT_OPEN.
+func requireOpen(pr *domain.PullRequest) error {
+	switch pr.Status {
+	case domain.StatusOpen:
+		return nil
+	case domain.StatusMerged:
+		return domain.ErrPRMerged
+	default:
+		return domain.NewPRNotOpenError(pr.Status)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    domain.Status
+		reviewers []string
+		action    statusAction
+		want      domain.Status
+	}{
+		{"черновик отмечается готовым к ревью", domain.StatusDraft, nil, actionMarkReady, domain.StatusOpen},
+		{"черновик закрывается", domain.StatusDraft, nil, actionClose, domain.StatusClosed},
+		{"OPEN PR мержится", domain.StatusOpen, []string{"u2"}, actionMerge, domain.StatusMerged},
+		{"OPEN PR закрывается", domain.StatusOpen, []string{"u2"}, actionClose, domain.StatusClosed},
+		{"закрытый PR с ревьюверами открывается в OPEN", domain.StatusClosed, []string{"u2"}, actionReopen, domain.StatusOpen},
+		{"закрытый PR без ревьюверов открывается в DRAFT", domain.StatusClosed, nil, actionReopen, domain.StatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := nextStatus(&domain.PullRequest{Status: tt.status, AssignedReviewers: tt.reviewers}, tt.action)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+
+	invalid := []struct {
+		name   string
+		status domain.Status
+		action statusAction
+	}{
+		{"черновик нельзя смержить", domain.StatusDraft, actionMerge},
+		{"OPEN PR нельзя отметить готовым повторно", domain.StatusOpen, actionMarkReady},
+		{"OPEN PR нельзя открыть заново", domain.StatusOpen, actionReopen},
+		{"закрытый PR нельзя смержить", domain.StatusClosed, actionMerge},
+		{"закрытый PR нельзя отметить готовым", domain.StatusClosed, actionMarkReady},
+		{"MERGED PR нельзя закрыть", domain.StatusMerged, actionClose},
+		{"MERGED PR нельзя открыть заново", domain.StatusMerged, actionReopen},
+	}
+
+	for _, tt := range invalid {
+		t.Run("ошибка: "+tt.name, func(t *testing.T) {
+			_, err := nextStatus(&domain.PullRequest{Status: tt.status}, tt.action)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, domain.ErrInvalidStatusTransition))
+		})
+	}
+}
+
+func TestRequireOpen(t *testing.T) {
+	assert.NoError(t, requireOpen(&domain.PullRequest{Status: domain.StatusOpen}))
+	assert.True(t, errors.Is(requireOpen(&domain.PullRequest{Status: domain.StatusMerged}), domain.ErrPRMerged))
+	assert.True(t, errors.Is(requireOpen(&domain.PullRequest{Status: domain.StatusDraft}), domain.ErrPRNotOpen))
+	assert.True(t, errors.Is(requireOpen(&domain.PullRequest{Status: domain.StatusClosed}), domain.ErrPRNotOpen))
+}
@@ -1,40 +1,589 @@
 package service
 
 import (
-	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
 	"math/rand"
+	"sort"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository/postgres"
 )
 
-// SelectReviewers выбирает до maxReviewers активных ревьюверов из команды, исключая excludeUserID
-func SelectReviewers(teamMembers []*domain.User, excludeUserID string, maxReviewers int) []string {
-	if maxReviewers <= 0 {
-		return []string{}
+// Названия стратегий выбора ревьюверов, используемые в конфигурации
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// SelectionRequest описывает параметры одного выбора ревьюверов
+type SelectionRequest struct {
+	Team           *domain.Team
+	TeamMembers    []*domain.User
+	ExcludeUserIDs []string
+	MaxReviewers   int
+	// Rand - источник случайности выбора. Выбор с одним и тем же источником (seed) и входными данными
+	// воспроизводим; если nil, используется общий генератор math/rand.
+	Rand *rand.Rand
+	// DryRun запрещает стратегии менять свое состояние (например, сдвигать курсор ротации)
+	DryRun bool
+	// AuthorID - автор PR, для которого выбираются ревьюверы
+	AuthorID string
+	// RecentReviews - сколько из последних PR автора ревьюил каждый кандидат (ID -> количество).
+	// Случайные стратегии понижают вероятность выбора таких кандидатов.
+	RecentReviews map[string]int
+	// PreferredUserIDs - кандидаты, выбираемые раньше остальных (например, с нужными навыками).
+	// Внутри обеих групп сохраняется порядок стратегии; состояние стратегии меняется один раз за выбор.
+	PreferredUserIDs []string
+	// Inputs, если задан, получает данные, прочитанные стратегией из БД (курсор ротации, нагрузку, историю ревью),
+	// чтобы выбор можно было повторить по ним (см. replayAssignment)
+	Inputs *domain.SelectionInputs
+}
+
+// ReviewerSelector выбирает ревьюверов для PR из участников команды
+type ReviewerSelector interface {
+	Select(ctx context.Context, req SelectionRequest) ([]string, error)
+	// Strategy возвращает название стратегии, которая применяется к команде team
+	Strategy(team *domain.Team) string
+}
+
+// txBoundSelector реализуют стратегии, которые читают или меняют данные в БД
+type txBoundSelector interface {
+	// WithTx возвращает копию стратегии, выполняющую все запросы в транзакции tx
+	WithTx(tx *sql.Tx) ReviewerSelector
+}
+
+// selectorWithTx привязывает selector к транзакции tx: нагрузка и история ревью читаются с учетом
+// изменений в tx, а курсор ротации сдвигается и откатывается вместе с ней.
+// Стратегии, не обращающиеся к БД, возвращаются без изменений.
+func selectorWithTx(selector ReviewerSelector, tx *sql.Tx) ReviewerSelector {
+	if bound, ok := selector.(txBoundSelector); ok {
+		return bound.WithTx(tx)
+	}
+	return selector
+}
+
+// simulatedSelector реализуют стратегии, состояние которых меняется от выбора к выбору
+type simulatedSelector interface {
+	// Simulated возвращает копию стратегии, меняющую свое состояние только в памяти
+	Simulated() ReviewerSelector
+}
+
+// selectorForSimulation возвращает копию selector для серии выборов без записи в БД:
+// состояние стратегий (курсор ротации) переходит от выбора к выбору, но не сохраняется.
+// Стратегии без состояния возвращаются без изменений.
+func selectorForSimulation(selector ReviewerSelector) ReviewerSelector {
+	if simulated, ok := selector.(simulatedSelector); ok {
+		return simulated.Simulated()
+	}
+	return selector
+}
+
+// eligibleCandidates возвращает активных участников команды, не находящихся в отсутствии, кроме excludeUserIDs
+func eligibleCandidates(teamMembers []*domain.User, excludeUserIDs []string) []*domain.User {
+	excluded := make(map[string]bool, len(excludeUserIDs))
+	for _, userID := range excludeUserIDs {
+		excluded[userID] = true
 	}
 
 	candidates := make([]*domain.User, 0)
 	for _, member := range teamMembers {
-		if member.IsActive && member.ID != excludeUserID {
+		if member.IsActive && !member.Unavailable && !excluded[member.ID] {
 			candidates = append(candidates, member)
 		}
 	}
+	return candidates
+}
 
-	if len(candidates) == 0 {
-		return []string{}
+// preferFirst переставляет кандидатов из preferredUserIDs в начало списка, сохраняя порядок внутри групп
+func preferFirst(candidates []*domain.User, preferredUserIDs []string) []*domain.User {
+	if len(preferredUserIDs) == 0 {
+		return candidates
 	}
 
-	count := len(candidates)
-	if count > maxReviewers {
-		count = maxReviewers
+	preferred := make(map[string]bool, len(preferredUserIDs))
+	for _, userID := range preferredUserIDs {
+		preferred[userID] = true
 	}
 
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	ordered := make([]*domain.User, 0, len(candidates))
+	rest := make([]*domain.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if preferred[candidate.ID] {
+			ordered = append(ordered, candidate)
+		} else {
+			rest = append(rest, candidate)
+		}
+	}
+	return append(ordered, rest...)
+}
+
+// takeIDs возвращает ID первых count кандидатов
+func takeIDs(candidates []*domain.User, count int) []string {
+	if count > len(candidates) {
+		count = len(candidates)
+	}
 
 	selected := make([]string, 0, count)
 	for i := 0; i < count; i++ {
 		selected = append(selected, candidates[i].ID)
 	}
-
 	return selected
 }
+
+type randomSelector struct{}
+
+// NewRandomSelector создает стратегию случайного выбора ревьюверов
+func NewRandomSelector() ReviewerSelector {
+	return &randomSelector{}
+}
+
+func (s *randomSelector) Strategy(_ *domain.Team) string {
+	return StrategyRandom
+}
+
+func (s *randomSelector) Select(_ context.Context, req SelectionRequest) ([]string, error) {
+	if req.MaxReviewers <= 0 {
+		return []string{}, nil
+	}
+
+	candidates := eligibleCandidates(req.TeamMembers, req.ExcludeUserIDs)
+	weightedShuffle(req.Rand, candidates, func(user *domain.User) float64 {
+		return user.EffectiveSelectionWeight() * repeatPenalty(req.RecentReviews[user.ID])
+	})
+	return takeIDs(preferFirst(candidates, req.PreferredUserIDs), req.MaxReviewers), nil
+}
+
+// repeatPenalty возвращает вес кандидата, ревьюившего reviews последних PR автора: 1, 1/2, 1/3, ...
+func repeatPenalty(reviews int) float64 {
+	return 1 / float64(1+reviews)
+}
+
+// weightedShuffle упорядочивает users случайно пропорционально весам weight (выбор без возвращения,
+// алгоритм Efraimidis-Spirakis): первые k элементов результата - взвешенная выборка k кандидатов.
+func weightedShuffle(rng *rand.Rand, users []*domain.User, weight func(user *domain.User) float64) {
+	keys := make(map[string]float64, len(users))
+	for _, user := range users {
+		u := 0.0
+		if rng == nil {
+			u = rand.Float64()
+		} else {
+			u = rng.Float64()
+		}
+		// ключ u^(1/w) в логарифмической форме; u = 0 дает -Inf и ставит кандидата в конец
+		keys[user.ID] = math.Log(u) / weight(user)
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return keys[users[i].ID] > keys[users[j].ID]
+	})
+}
+
+type roundRobinSelector struct {
+	rotationRepo repository.RotationRepository
+}
+
+// NewRoundRobinSelector создает стратегию выбора ревьюверов по кругу.
+// Для каждой команды в БД хранится ID последнего выбранного ревьювера,
+// следующий выбор начинается с участника, идущего за ним. При req.DryRun курсор только читается.
+func NewRoundRobinSelector(rotationRepo repository.RotationRepository) ReviewerSelector {
+	return &roundRobinSelector{rotationRepo: rotationRepo}
+}
+
+func (s *roundRobinSelector) Strategy(_ *domain.Team) string {
+	return StrategyRoundRobin
+}
+
+func (s *roundRobinSelector) WithTx(tx *sql.Tx) ReviewerSelector {
+	return NewRoundRobinSelector(postgres.NewRotationRepositoryWithTx(tx))
+}
+
+func (s *roundRobinSelector) Simulated() ReviewerSelector {
+	return NewRoundRobinSelector(&simulatedRotation{rotationRepo: s.rotationRepo, cursors: make(map[int]string)})
+}
+
+func (s *roundRobinSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	if req.MaxReviewers <= 0 || req.Team == nil {
+		return []string{}, nil
+	}
+
+	candidates := eligibleCandidates(req.TeamMembers, req.ExcludeUserIDs)
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	var selected []string
+	next := func(cursorID string) (string, error) {
+		if req.Inputs != nil {
+			req.Inputs.CursorID = cursorID
+		}
+
+		start := nextAfterCursor(req.TeamMembers, candidates, cursorID)
+		rotated := make([]*domain.User, 0, len(candidates))
+		rotated = append(rotated, candidates[start:]...)
+		rotated = append(rotated, candidates[:start]...)
+
+		selected = takeIDs(preferFirst(rotated, req.PreferredUserIDs), req.MaxReviewers)
+		return selected[len(selected)-1], nil
+	}
+
+	if req.DryRun {
+		cursorID, err := s.rotationRepo.GetCursor(ctx, req.Team.ID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = next(cursorID)
+		return selected, err
+	}
+
+	err := s.rotationRepo.AdvanceCursor(ctx, req.Team.ID, next)
+	if err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
+
+// nextAfterCursor возвращает индекс первого кандидата, идущего в списке команды после cursorID
+func nextAfterCursor(teamMembers []*domain.User, candidates []*domain.User, cursorID string) int {
+	if cursorID == "" {
+		return 0
+	}
+
+	position := make(map[string]int, len(teamMembers))
+	for i, member := range teamMembers {
+		position[member.ID] = i
+	}
+
+	cursorPos, ok := position[cursorID]
+	if !ok {
+		return 0
+	}
+
+	for i, candidate := range candidates {
+		if position[candidate.ID] > cursorPos {
+			return i
+		}
+	}
+	return 0
+}
+
+// simulatedRotation хранит курсоры ротации в памяти. Курсор команды, еще не сдвинутый
+// в симуляции, читается из rotationRepo; сдвиги в rotationRepo не записываются.
+type simulatedRotation struct {
+	rotationRepo repository.RotationRepository
+	cursors      map[int]string
+}
+
+func (r *simulatedRotation) AdvanceCursor(ctx context.Context, teamID int, next func(cursorID string) (string, error)) error {
+	cursorID, err := r.GetCursor(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	newCursorID, err := next(cursorID)
+	if err != nil {
+		return err
+	}
+
+	r.cursors[teamID] = newCursorID
+	return nil
+}
+
+func (r *simulatedRotation) GetCursor(ctx context.Context, teamID int) (string, error) {
+	if cursorID, ok := r.cursors[teamID]; ok {
+		return cursorID, nil
+	}
+	return r.rotationRepo.GetCursor(ctx, teamID)
+}
+
+type leastLoadedSelector struct {
+	pullRequestRepo repository.PullRequestRepository
+	// loads, если задана, используется вместо нагрузки из БД (при повторе выбора по сохраненным данным)
+	loads map[string]int
+	// simulatedPicks, если задана, - сколько раз пользователи уже выбраны в симуляции.
+	// Прибавляется к нагрузке, как если бы выбранные ранее PR были созданы.
+	simulatedPicks map[string]int
+}
+
+// NewLeastLoadedSelector создает стратегию, выбирающую ревьюверов с наименьшим числом OPEN PR на ревью.
+// При равной нагрузке предпочтение отдается тем, кто реже ревьюил последние PR автора, далее выбор случайный с учетом весов.
+func NewLeastLoadedSelector(pullRequestRepo repository.PullRequestRepository) ReviewerSelector {
+	return &leastLoadedSelector{pullRequestRepo: pullRequestRepo}
+}
+
+func (s *leastLoadedSelector) Strategy(_ *domain.Team) string {
+	return StrategyLeastLoaded
+}
+
+func (s *leastLoadedSelector) WithTx(tx *sql.Tx) ReviewerSelector {
+	return NewLeastLoadedSelector(postgres.NewPullRequestRepositoryWithTx(tx))
+}
+
+func (s *leastLoadedSelector) Simulated() ReviewerSelector {
+	return &leastLoadedSelector{
+		pullRequestRepo: s.pullRequestRepo,
+		loads:           s.loads,
+		simulatedPicks:  make(map[string]int),
+	}
+}
+
+func (s *leastLoadedSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	if req.MaxReviewers <= 0 {
+		return []string{}, nil
+	}
+
+	candidates := eligibleCandidates(req.TeamMembers, req.ExcludeUserIDs)
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	loads := s.loads
+	if loads == nil {
+		var err error
+		loads, err = loadOpenReviewCounts(ctx, s.pullRequestRepo, candidates)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.simulatedPicks != nil {
+		simulatedLoads := make(map[string]int, len(candidates))
+		for _, candidate := range candidates {
+			simulatedLoads[candidate.ID] = loads[candidate.ID] + s.simulatedPicks[candidate.ID]
+		}
+		loads = simulatedLoads
+	}
+	if req.Inputs != nil {
+		req.Inputs.Loads = make(map[string]int, len(candidates))
+		for _, candidate := range candidates {
+			req.Inputs.Loads[candidate.ID] = loads[candidate.ID]
+		}
+	}
+
+	weightedShuffle(req.Rand, candidates, (*domain.User).EffectiveSelectionWeight)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if loads[candidates[i].ID] != loads[candidates[j].ID] {
+			return loads[candidates[i].ID] < loads[candidates[j].ID]
+		}
+		return req.RecentReviews[candidates[i].ID] < req.RecentReviews[candidates[j].ID]
+	})
+
+	selected := takeIDs(preferFirst(candidates, req.PreferredUserIDs), req.MaxReviewers)
+	if s.simulatedPicks != nil {
+		for _, userID := range selected {
+			s.simulatedPicks[userID]++
+		}
+	}
+	return selected, nil
+}
+
+// loadOpenReviewCounts загружает количество OPEN PR на ревью для команд, к которым относятся users
+func loadOpenReviewCounts(ctx context.Context, pullRequestRepo repository.PullRequestRepository, users []*domain.User) (map[string]int, error) {
+	loads := make(map[string]int, len(users))
+	loadedTeams := make(map[int]bool)
+	for _, user := range users {
+		if loadedTeams[user.TeamID] {
+			continue
+		}
+		loadedTeams[user.TeamID] = true
+
+		counts, err := pullRequestRepo.GetOpenReviewCountsByTeamID(ctx, user.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		for userID, count := range counts {
+			loads[userID] = count
+		}
+	}
+	return loads, nil
+}
+
+// withoutSaturated убирает из users тех, кто достиг ограничения на количество OPEN PR на ревью.
+// Вторым значением возвращается количество активных кандидатов (не из excludeUserIDs), пропущенных из-за ограничения.
+func withoutSaturated(
+	ctx context.Context,
+	pullRequestRepo repository.PullRequestRepository,
+	users []*domain.User,
+	excludeUserIDs []string,
+) ([]*domain.User, int, error) {
+	hasLimits := false
+	for _, user := range users {
+		if user.MaxOpenReviews != nil {
+			hasLimits = true
+			break
+		}
+	}
+	if !hasLimits {
+		return users, 0, nil
+	}
+
+	loads, err := loadOpenReviewCounts(ctx, pullRequestRepo, users)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	eligible := make(map[string]bool)
+	for _, candidate := range eligibleCandidates(users, excludeUserIDs) {
+		eligible[candidate.ID] = true
+	}
+
+	available := make([]*domain.User, 0, len(users))
+	saturated := 0
+	for _, user := range users {
+		if user.AtCapacity(loads[user.ID]) {
+			if eligible[user.ID] {
+				saturated++
+			}
+			continue
+		}
+		available = append(available, user)
+	}
+
+	return available, saturated, nil
+}
+
+type teamStrategySelector struct {
+	defaultSelector ReviewerSelector
+	teamSelectors   map[string]ReviewerSelector
+}
+
+// NewTeamStrategySelector создает селектор, использующий для каждой команды свою стратегию.
+// defaultStrategy применяется к командам, для которых стратегия не задана в teamStrategies.
+func NewTeamStrategySelector(
+	defaultStrategy string,
+	teamStrategies map[string]string,
+	pullRequestRepo repository.PullRequestRepository,
+	rotationRepo repository.RotationRepository,
+) (ReviewerSelector, error) {
+	strategies := map[string]ReviewerSelector{
+		StrategyRandom:      NewRandomSelector(),
+		StrategyRoundRobin:  NewRoundRobinSelector(rotationRepo),
+		StrategyLeastLoaded: NewLeastLoadedSelector(pullRequestRepo),
+	}
+
+	defaultSelector, ok := strategies[defaultStrategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown reviewer selection strategy %q", defaultStrategy)
+	}
+
+	teamSelectors := make(map[string]ReviewerSelector, len(teamStrategies))
+	for teamName, strategy := range teamStrategies {
+		selector, ok := strategies[strategy]
+		if !ok {
+			return nil, fmt.Errorf("unknown reviewer selection strategy %q for team %q", strategy, teamName)
+		}
+		teamSelectors[teamName] = selector
+	}
+
+	return &teamStrategySelector{
+		defaultSelector: defaultSelector,
+		teamSelectors:   teamSelectors,
+	}, nil
+}
+
+func (s *teamStrategySelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	return s.selectorFor(req.Team).Select(ctx, req)
+}
+
+func (s *teamStrategySelector) Strategy(team *domain.Team) string {
+	return s.selectorFor(team).Strategy(team)
+}
+
+func (s *teamStrategySelector) WithTx(tx *sql.Tx) ReviewerSelector {
+	teamSelectors := make(map[string]ReviewerSelector, len(s.teamSelectors))
+	for teamName, selector := range s.teamSelectors {
+		teamSelectors[teamName] = selectorWithTx(selector, tx)
+	}
+	return &teamStrategySelector{
+		defaultSelector: selectorWithTx(s.defaultSelector, tx),
+		teamSelectors:   teamSelectors,
+	}
+}
+
+// Simulated создает одну копию на каждую стратегию: команды с одной стратегией, как и вне симуляции,
+// делят ее состояние (курсоры ротации, выбранных в предыдущих повторах).
+func (s *teamStrategySelector) Simulated() ReviewerSelector {
+	copies := make(map[ReviewerSelector]ReviewerSelector)
+	simulated := func(selector ReviewerSelector) ReviewerSelector {
+		if _, ok := copies[selector]; !ok {
+			copies[selector] = selectorForSimulation(selector)
+		}
+		return copies[selector]
+	}
+
+	teamSelectors := make(map[string]ReviewerSelector, len(s.teamSelectors))
+	for teamName, selector := range s.teamSelectors {
+		teamSelectors[teamName] = simulated(selector)
+	}
+	return &teamStrategySelector{
+		defaultSelector: simulated(s.defaultSelector),
+		teamSelectors:   teamSelectors,
+	}
+}
+
+// selectorFor возвращает стратегию команды team или стратегию по умолчанию
+func (s *teamStrategySelector) selectorFor(team *domain.Team) ReviewerSelector {
+	if team != nil {
+		if selector, ok := s.teamSelectors[team.Name]; ok {
+			return selector
+		}
+	}
+	return s.defaultSelector
+}
+
+type antiRepetitionSelector struct {
+	selector        ReviewerSelector
+	pullRequestRepo repository.PullRequestRepository
+	lookback        int
+}
+
+// NewAntiRepetitionSelector оборачивает selector так, чтобы реже выбирались кандидаты,
+// ревьюившие последние lookback PR того же автора. При lookback <= 0 selector возвращается без изменений.
+func NewAntiRepetitionSelector(selector ReviewerSelector, pullRequestRepo repository.PullRequestRepository, lookback int) ReviewerSelector {
+	if lookback <= 0 {
+		return selector
+	}
+	return &antiRepetitionSelector{
+		selector:        selector,
+		pullRequestRepo: pullRequestRepo,
+		lookback:        lookback,
+	}
+}
+
+func (s *antiRepetitionSelector) Strategy(team *domain.Team) string {
+	return s.selector.Strategy(team)
+}
+
+func (s *antiRepetitionSelector) WithTx(tx *sql.Tx) ReviewerSelector {
+	return &antiRepetitionSelector{
+		selector:        selectorWithTx(s.selector, tx),
+		pullRequestRepo: postgres.NewPullRequestRepositoryWithTx(tx),
+		lookback:        s.lookback,
+	}
+}
+
+func (s *antiRepetitionSelector) Simulated() ReviewerSelector {
+	return &antiRepetitionSelector{
+		selector:        selectorForSimulation(s.selector),
+		pullRequestRepo: s.pullRequestRepo,
+		lookback:        s.lookback,
+	}
+}
+
+func (s *antiRepetitionSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	if req.AuthorID != "" && req.MaxReviewers > 0 {
+		recentReviews, err := s.pullRequestRepo.GetRecentReviewCounts(ctx, req.AuthorID, s.lookback)
+		if err != nil {
+			return nil, err
+		}
+		req.RecentReviews = recentReviews
+		if req.Inputs != nil {
+			req.Inputs.RecentReviews = recentReviews
+		}
+	}
+	return s.selector.Select(ctx, req)
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testTeamMembers() []*domain.User {
+	return []*domain.User{
+		{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: false},
+		{ID: "u4", Username: "Dave", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+}
+
+func TestRandomSelector_Select(t *testing.T) {
+	// selectRandom выбирает до maxReviewers ревьюверов из members, исключая автора excludeUserID
+	selectRandom := func(rng *rand.Rand, members []*domain.User, excludeUserID string, maxReviewers int) []string {
+		selected, err := NewRandomSelector().Select(context.Background(), SelectionRequest{
+			TeamMembers:    members,
+			ExcludeUserIDs: []string{excludeUserID},
+			MaxReviewers:   maxReviewers,
+			Rand:           rng,
+		})
+		require.NoError(t, err)
+		return selected
+	}
+
+	t.Run("исключает автора и неактивных", func(t *testing.T) {
+		selected := selectRandom(nil, testTeamMembers(), "u1", 5)
+
+		assert.ElementsMatch(t, []string{"u2", "u4"}, selected)
+	})
+
+	t.Run("исключает отсутствующих по расписанию", func(t *testing.T) {
+		members := testTeamMembers()
+		members[1].Unavailable = true
+
+		selected := selectRandom(nil, members, "u1", 5)
+
+		assert.Equal(t, []string{"u4"}, selected)
+	})
+
+	t.Run("не больше maxReviewers", func(t *testing.T) {
+		selected := selectRandom(nil, testTeamMembers(), "u1", 1)
+
+		assert.Len(t, selected, 1)
+		assert.NotContains(t, selected, "u1")
+		assert.NotContains(t, selected, "u3")
+	})
+
+	t.Run("пустой результат при maxReviewers = 0", func(t *testing.T) {
+		assert.Empty(t, selectRandom(nil, testTeamMembers(), "u1", 0))
+	})
+
+	t.Run("пользователь с меньшим весом выбирается реже", func(t *testing.T) {
+		members := testTeamMembers()
+		members[1].SelectionWeight = 0.3
+		picks := map[string]int{}
+
+		for seed := int64(0); seed < 300; seed++ {
+			selected := selectRandom(newSeededRand(seed), members, "u1", 1)
+			require.Len(t, selected, 1)
+			picks[selected[0]]++
+		}
+
+		// вероятность выбора u2 - 0.3 / 1.3, то есть ожидается около 70 выборов из 300
+		assert.Less(t, picks["u2"], 110)
+		assert.Greater(t, picks["u2"], 30)
+		assert.Equal(t, 300, picks["u2"]+picks["u4"])
+	})
+
+	t.Run("выбор без возвращения берет всех при нехватке кандидатов", func(t *testing.T) {
+		members := testTeamMembers()
+		members[1].SelectionWeight = 0.3
+
+		selected := selectRandom(newSeededRand(1), members, "u1", 2)
+
+		assert.ElementsMatch(t, []string{"u2", "u4"}, selected)
+	})
+
+	t.Run("одинаковый seed дает одинаковый выбор", func(t *testing.T) {
+		members := append(testTeamMembers(),
+			&domain.User{ID: "u5", Username: "Eve", TeamID: 1, TeamName: "backend", IsActive: true},
+			&domain.User{ID: "u6", Username: "Frank", TeamID: 1, TeamName: "backend", IsActive: true},
+		)
+
+		for seed := int64(0); seed < 20; seed++ {
+			first := selectRandom(newSeededRand(seed), members, "u1", 2)
+			second := selectRandom(newSeededRand(seed), members, "u1", 2)
+
+			assert.Equal(t, first, second, "seed %d", seed)
+		}
+	})
+}
+
+func TestRandomSelector_RecentReviews(t *testing.T) {
+	t.Run("частый ревьювер автора выбирается реже", func(t *testing.T) {
+		selector := NewRandomSelector()
+		picks := map[string]int{}
+
+		for seed := int64(0); seed < 300; seed++ {
+			selected, err := selector.Select(context.Background(), SelectionRequest{
+				Team:           &domain.Team{ID: 1, Name: "backend"},
+				TeamMembers:    testTeamMembers(),
+				ExcludeUserIDs: []string{"u1"},
+				MaxReviewers:   1,
+				Rand:           newSeededRand(seed),
+				AuthorID:       "u1",
+				RecentReviews:  map[string]int{"u2": 4},
+			})
+			require.NoError(t, err)
+			require.Len(t, selected, 1)
+			picks[selected[0]]++
+		}
+
+		// вес u2 - 1/5 от веса u4, то есть ожидается около 50 выборов из 300
+		assert.Less(t, picks["u2"], 100)
+		assert.Greater(t, picks["u2"], 0, "понижение веса не исключает кандидата полностью")
+		assert.Equal(t, 300, picks["u2"]+picks["u4"])
+	})
+
+	t.Run("одинаковый seed дает одинаковый выбор", func(t *testing.T) {
+		selector := NewRandomSelector()
+		req := func(seed int64) SelectionRequest {
+			return SelectionRequest{
+				TeamMembers:   testTeamMembers(),
+				MaxReviewers:  2,
+				Rand:          newSeededRand(seed),
+				RecentReviews: map[string]int{"u1": 2, "u4": 1},
+			}
+		}
+
+		for seed := int64(0); seed < 20; seed++ {
+			first, err := selector.Select(context.Background(), req(seed))
+			require.NoError(t, err)
+			second, err := selector.Select(context.Background(), req(seed))
+			require.NoError(t, err)
+
+			assert.Equal(t, first, second, "seed %d", seed)
+		}
+	})
+}
+
+func TestAntiRepetitionSelector(t *testing.T) {
+	t.Run("передает стратегии число недавних ревью автора", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		selector := NewAntiRepetitionSelector(NewLeastLoadedSelector(mockPRRepo), mockPRRepo, 5)
+
+		mockPRRepo.On("GetRecentReviewCounts", mock.Anything, "u1", 5).Return(map[string]int{"u2": 2}, nil).Once()
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{"u2": 0, "u4": 0}, nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u1"},
+			MaxReviewers:   1,
+			AuthorID:       "u1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4"}, selected, "при равной нагрузке выбирается тот, кто реже ревьюил автора")
+		assert.Equal(t, StrategyLeastLoaded, selector.Strategy(&domain.Team{ID: 1}))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("lookback = 0 отключает правило", func(t *testing.T) {
+		inner := NewRandomSelector()
+
+		assert.Same(t, inner, NewAntiRepetitionSelector(inner, new(mocks.MockPullRequestRepository), 0))
+	})
+
+	t.Run("ошибка: не удалось получить историю ревью", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		selector := NewAntiRepetitionSelector(NewRandomSelector(), mockPRRepo, 5)
+
+		mockPRRepo.On("GetRecentReviewCounts", mock.Anything, "u1", 5).Return(nil, errors.New("connection refused")).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			TeamMembers:  testTeamMembers(),
+			MaxReviewers: 1,
+			AuthorID:     "u1",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, selected)
+		mockPRRepo.AssertExpectations(t)
+	})
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	t.Run("первый выбор начинается с начала команды", func(t *testing.T) {
+		mockRotationRepo := new(mocks.MockRotationRepository)
+		selector := NewRoundRobinSelector(mockRotationRepo)
+
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("", nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u4"},
+			MaxReviewers:   1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, selected)
+		assert.Equal(t, "u1", mockRotationRepo.Cursor)
+		mockRotationRepo.AssertExpectations(t)
+	})
+
+	t.Run("выбор продолжается после курсора с переходом в начало", func(t *testing.T) {
+		mockRotationRepo := new(mocks.MockRotationRepository)
+		selector := NewRoundRobinSelector(mockRotationRepo)
+
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("u2", nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u1"},
+			MaxReviewers:   2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u2"}, selected, "неактивный u3 и автор u1 пропускаются")
+		assert.Equal(t, "u2", mockRotationRepo.Cursor)
+		mockRotationRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run читает курсор, не сдвигая его", func(t *testing.T) {
+		mockRotationRepo := new(mocks.MockRotationRepository)
+		selector := NewRoundRobinSelector(mockRotationRepo)
+
+		mockRotationRepo.On("GetCursor", mock.Anything, 1).Return("u2", nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u1"},
+			MaxReviewers:   1,
+			DryRun:         true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4"}, selected)
+		mockRotationRepo.AssertNotCalled(t, "AdvanceCursor", mock.Anything, mock.Anything)
+		mockRotationRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: не удалось сдвинуть курсор", func(t *testing.T) {
+		mockRotationRepo := new(mocks.MockRotationRepository)
+		selector := NewRoundRobinSelector(mockRotationRepo)
+
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("", errors.New("connection refused")).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u1"},
+			MaxReviewers:   2,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, selected)
+		mockRotationRepo.AssertExpectations(t)
+	})
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	t.Run("выбирает наименее загруженных", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{
+			"u1": 0,
+			"u2": 2,
+			"u3": 0,
+			"u4": 1,
+		}, nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u1"},
+			MaxReviewers:   1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4"}, selected)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("при равной нагрузке выбор среди наименее загруженных", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{
+			"u1": 3,
+			"u2": 0,
+			"u4": 0,
+		}, nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: nil,
+			MaxReviewers:   2,
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u4"}, selected)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("симуляция учитывает выбранных в предыдущих повторах только в копии", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+		simulated := selectorForSimulation(selector)
+
+		mockPRRepo.On("GetOpenReviewCountsByTeamID", mock.Anything, 1).Return(map[string]int{"u2": 2, "u4": 0}, nil)
+		req := SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u1"},
+			MaxReviewers:   1,
+		}
+
+		picks := map[string]int{}
+		for i := 0; i < 4; i++ {
+			selected, err := simulated.Select(context.Background(), req)
+			require.NoError(t, err)
+			require.Len(t, selected, 1)
+			picks[selected[0]]++
+		}
+		// u4 выбирается, пока его нагрузка с учетом симуляции не сравняется с u2 (2), затем по очереди
+		assert.Equal(t, map[string]int{"u2": 1, "u4": 3}, picks)
+
+		selected, err := selector.Select(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4"}, selected, "выборы симуляции не меняют нагрузку исходной стратегии")
+	})
+}
+
+func TestTeamStrategySelector(t *testing.T) {
+	t.Run("ошибка: неизвестная стратегия", func(t *testing.T) {
+		_, err := NewTeamStrategySelector("unknown", nil, new(mocks.MockPullRequestRepository), new(mocks.MockRotationRepository))
+		require.Error(t, err)
+
+		_, err = NewTeamStrategySelector(StrategyRandom, map[string]string{"backend": "unknown"}, new(mocks.MockPullRequestRepository), new(mocks.MockRotationRepository))
+		require.Error(t, err)
+	})
+
+	t.Run("стратегия команды переопределяет стратегию по умолчанию", func(t *testing.T) {
+		mockRotationRepo := new(mocks.MockRotationRepository)
+		selector, err := NewTeamStrategySelector(StrategyRandom, map[string]string{"backend": StrategyRoundRobin}, new(mocks.MockPullRequestRepository), mockRotationRepo)
+		require.NoError(t, err)
+
+		mockRotationRepo.On("AdvanceCursor", mock.Anything, 1).Return("", nil).Once()
+
+		selected, err := selector.Select(context.Background(), SelectionRequest{
+			Team:           &domain.Team{ID: 1, Name: "backend"},
+			TeamMembers:    testTeamMembers(),
+			ExcludeUserIDs: []string{"u4"},
+			MaxReviewers:   2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2"}, selected)
+		mockRotationRepo.AssertExpectations(t)
+	})
+	t.Run("в симуляции команды с одной стратегией делят ее копию", func(t *testing.T) {
+		selector, err := NewTeamStrategySelector(StrategyLeastLoaded, map[string]string{"backend": StrategyLeastLoaded, "mobile": StrategyRandom}, new(mocks.MockPullRequestRepository), new(mocks.MockRotationRepository))
+		require.NoError(t, err)
+
+		simulated, ok := selectorForSimulation(selector).(*teamStrategySelector)
+		require.True(t, ok)
+
+		assert.Same(t, simulated.defaultSelector, simulated.teamSelectors["backend"])
+		assert.NotSame(t, selector.(*teamStrategySelector).defaultSelector, simulated.defaultSelector)
+		assert.Equal(t, StrategyRandom, simulated.teamSelectors["mobile"].Strategy(nil))
+	})
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository"
+)
+
+// maxTagLength совпадает с размером колонок tag в user_skills и pull_request_tags
+const maxTagLength = 50
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]*$`)
+
+// normalizeTags приводит теги навыков к нижнему регистру, убирает повторы и сортирует.
+// Допустимы латинские буквы, цифры и символы + # . _ -
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if len(tag) > maxTagLength || !tagPattern.MatchString(tag) {
+			return nil, domain.NewBadRequestError("invalid tag " + strconv.Quote(tag))
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// loadSkills заполняет Skills у users, загружая навыки по командам, к которым они относятся
+func loadSkills(ctx context.Context, userRepo repository.UserRepository, users []*domain.User) error {
+	loadedTeams := make(map[int]map[string][]string)
+	for _, user := range users {
+		skills, ok := loadedTeams[user.TeamID]
+		if !ok {
+			var err error
+			skills, err = userRepo.GetSkillsByTeamID(ctx, user.TeamID)
+			if err != nil {
+				return err
+			}
+			loadedTeams[user.TeamID] = skills
+		}
+		user.Skills = skills[user.ID]
+	}
+	return nil
+}
@@ -19,6 +19,11 @@ func (s *statsService) GetReviewerStats(ctx context.Context) ([]*domain.Reviewer
 	return s.statsRepo.GetReviewerStats(ctx)
 }
 
+// GetAuthorStats возвращает количество PR, в которых каждый пользователь автор или соавтор
+func (s *statsService) GetAuthorStats(ctx context.Context) ([]*domain.AuthorStat, error) {
+	return s.statsRepo.GetAuthorStats(ctx)
+}
+
 func (s *statsService) GetPRStatsByStatus(ctx context.Context) ([]*domain.PRStatusStat, error) {
 	return s.statsRepo.GetPRStatsByStatus(ctx)
 }
@@ -9,4 +9,8 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, name string) (*domain.Team, error)
+	UpdateSettings(ctx context.Context, name string, minReviewers, maxReviewers int) (*domain.Team, error)
+	SetFallbackTeams(ctx context.Context, name string, fallbackTeamNames []string) (*domain.Team, error)
+	SetMandatoryReviewers(ctx context.Context, name string, userIDs []string) (*domain.Team, error)
+	SetMergePolicy(ctx context.Context, name string, policy domain.MergePolicy) (*domain.Team, error)
 }
@@ -31,7 +31,24 @@ func stringIDToInt(stringID string) (int, error) {
 	return strconv.Atoi(idStr)
 }
 
+// validateReviewerLimits проверяет, что 0 <= minReviewers <= maxReviewers и maxReviewers >= 1
+func validateReviewerLimits(minReviewers, maxReviewers int) error {
+	if maxReviewers < 1 {
+		return domain.NewBadRequestError("max_reviewers must be at least 1")
+	}
+	if minReviewers < 0 || minReviewers > maxReviewers {
+		return domain.NewBadRequestError("min_reviewers must be between 0 and max_reviewers")
+	}
+	return nil
+}
+
+// CreateTeam создает команду с участниками. Лимиты ревьюверов берутся из team как есть:
+// значения по умолчанию для не переданных полей подставляет вызывающий код.
 func (s *teamService) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	if err := validateReviewerLimits(team.MinReviewers, team.MaxReviewers); err != nil {
+		return nil, err
+	}
+
 	existingTeam, err := s.teamRepo.GetByName(ctx, team.Name)
 	if err == nil && existingTeam != nil {
 		return nil, domain.ErrTeamExists
@@ -111,9 +128,10 @@ func (s *teamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 	createdTeam.Members = make([]domain.TeamMember, 0, len(users))
 	for _, user := range users {
 		createdTeam.Members = append(createdTeam.Members, domain.TeamMember{
-			UserID:   user.ID,
-			Username: user.Username,
-			IsActive: user.IsActive,
+			UserID:          user.ID,
+			Username:        user.Username,
+			IsActive:        user.IsActive,
+			SelectionWeight: user.EffectiveSelectionWeight(),
 		})
 	}
 
@@ -137,11 +155,189 @@ func (s *teamService) GetTeam(ctx context.Context, name string) (*domain.Team, e
 	team.Members = make([]domain.TeamMember, 0, len(users))
 	for _, user := range users {
 		team.Members = append(team.Members, domain.TeamMember{
-			UserID:   user.ID,
-			Username: user.Username,
-			IsActive: user.IsActive,
+			UserID:          user.ID,
+			Username:        user.Username,
+			IsActive:        user.IsActive,
+			SelectionWeight: user.EffectiveSelectionWeight(),
 		})
 	}
 
+	fallbackTeams, err := s.teamRepo.GetFallbackTeams(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	team.FallbackTeams = make([]string, 0, len(fallbackTeams))
+	for _, fallbackTeam := range fallbackTeams {
+		team.FallbackTeams = append(team.FallbackTeams, fallbackTeam.Name)
+	}
+
+	team.MandatoryReviewers, err = s.teamRepo.GetMandatoryReviewers(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	team.MergePolicy, err = s.teamRepo.GetMergePolicy(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return team, nil
 }
+
+// UpdateSettings изменяет минимальное и максимальное количество ревьюверов для PR команды.
+// max_reviewers нельзя опустить ниже required_approvals из политики merge: иначе PR команды нельзя будет смержить.
+func (s *teamService) UpdateSettings(ctx context.Context, name string, minReviewers, maxReviewers int) (*domain.Team, error) {
+	if err := validateReviewerLimits(minReviewers, maxReviewers); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + name)
+		}
+		return nil, err
+	}
+
+	policy, err := s.teamRepo.GetMergePolicy(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	if policy.RequiredApprovals > maxReviewers {
+		return nil, domain.NewBadRequestError("max_reviewers must not be less than required_approvals of the merge policy")
+	}
+
+	err = s.teamRepo.UpdateSettings(ctx, team.ID, minReviewers, maxReviewers)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + name)
+		}
+		return nil, err
+	}
+
+	return s.GetTeam(ctx, name)
+}
+
+// SetFallbackTeams задает резервные команды, из которых добираются ревьюверы,
+// если в команде name не хватает кандидатов. Приоритет определяется порядком fallbackTeamNames.
+func (s *teamService) SetFallbackTeams(ctx context.Context, name string, fallbackTeamNames []string) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + name)
+		}
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(fallbackTeamNames))
+	fallbackTeamIDs := make([]int, 0, len(fallbackTeamNames))
+	for _, fallbackTeamName := range fallbackTeamNames {
+		if fallbackTeamName == name {
+			return nil, domain.NewBadRequestError("team cannot be its own fallback")
+		}
+		if seen[fallbackTeamName] {
+			return nil, domain.NewBadRequestError("duplicate fallback team " + fallbackTeamName)
+		}
+		seen[fallbackTeamName] = true
+
+		fallbackTeam, err := s.teamRepo.GetByName(ctx, fallbackTeamName)
+		if err != nil {
+			if err.Error() == "team not found" {
+				return nil, domain.NewNotFoundError("team with name " + fallbackTeamName)
+			}
+			return nil, err
+		}
+		fallbackTeamIDs = append(fallbackTeamIDs, fallbackTeam.ID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = postgres.NewTeamRepositoryWithTx(tx).SetFallbackTeams(ctx, team.ID, fallbackTeamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(ctx, name)
+}
+
+// SetMandatoryReviewers задает обязательных ревьюверов, которые назначаются на каждый PR участников команды name.
+// Обязательным ревьювером может быть пользователь из любой команды.
+func (s *teamService) SetMandatoryReviewers(ctx context.Context, name string, userIDs []string) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + name)
+		}
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			return nil, domain.NewBadRequestError("duplicate mandatory reviewer " + userID)
+		}
+		seen[userID] = true
+
+		_, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+				return nil, domain.NewNotFoundError("user with id " + userID)
+			}
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = postgres.NewTeamRepositoryWithTx(tx).SetMandatoryReviewers(ctx, team.ID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(ctx, name)
+}
+
+// SetMergePolicy задает условия, при которых PR участников команды name можно перевести в MERGED.
+// required_approvals не может превышать max_reviewers команды: столько одобрений PR никогда не наберет.
+func (s *teamService) SetMergePolicy(ctx context.Context, name string, policy domain.MergePolicy) (*domain.Team, error) {
+	if policy.RequiredApprovals < 0 {
+		return nil, domain.NewBadRequestError("required_approvals must be non-negative")
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		if err.Error() == "team not found" {
+			return nil, domain.NewNotFoundError("team with name " + name)
+		}
+		return nil, err
+	}
+	if policy.RequiredApprovals > team.MaxReviewers {
+		return nil, domain.NewBadRequestError("required_approvals must not exceed max_reviewers")
+	}
+
+	err = s.teamRepo.SetMergePolicy(ctx, team.ID, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(ctx, name)
+}
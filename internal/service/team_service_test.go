@@ -25,7 +25,9 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		ctx := context.Background()
 
 		team := &domain.Team{
-			Name: "backend",
+			Name:         "backend",
+			MinReviewers: domain.DefaultMinReviewers,
+			MaxReviewers: domain.DefaultMaxReviewers,
 			Members: []domain.TeamMember{
 				{UserID: "u1", Username: "Alice", IsActive: true},
 				{UserID: "u2", Username: "Bob", IsActive: true},
@@ -46,7 +48,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(nil, errors.New("team not found")).Once()
 
 		mockDB.ExpectBegin()
-		mockDB.ExpectQuery(`INSERT INTO teams`).WithArgs("backend", sqlmock.AnyArg()).
+		mockDB.ExpectQuery(`INSERT INTO teams`).WithArgs("backend", sqlmock.AnyArg(), domain.DefaultMinReviewers, domain.DefaultMaxReviewers).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, time.Now(), nil))
 		mockDB.ExpectQuery(`UPDATE users`).WithArgs(sqlmock.AnyArg(), "Alice", 1, true, sqlmock.AnyArg()).
 			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), nil))
@@ -71,6 +73,31 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		require.NoError(t, mockDB.ExpectationsWereMet())
 	})
 
+	t.Run("ошибка: явно переданные нулевые лимиты ревьюверов", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		team := &domain.Team{
+			Name:         "backend",
+			MinReviewers: 0,
+			MaxReviewers: 0,
+			Members: []domain.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		}
+
+		result, err := service.CreateTeam(context.Background(), team)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockTeamRepo.AssertNotCalled(t, "GetByName", mock.Anything, mock.Anything)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
 	t.Run("ошибка: команда уже существует", func(t *testing.T) {
 		db, _ := setupMockDBForService(t)
 		mockTeamRepo := new(mocks.MockTeamRepository)
@@ -80,7 +107,9 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		ctx := context.Background()
 
 		team := &domain.Team{
-			Name: "backend",
+			Name:         "backend",
+			MinReviewers: domain.DefaultMinReviewers,
+			MaxReviewers: domain.DefaultMaxReviewers,
 			Members: []domain.TeamMember{
 				{UserID: "u1", Username: "Alice", IsActive: true},
 			},
@@ -115,7 +144,9 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		ctx := context.Background()
 
 		team := &domain.Team{
-			Name: "backend",
+			Name:         "backend",
+			MinReviewers: domain.DefaultMinReviewers,
+			MaxReviewers: domain.DefaultMaxReviewers,
 			Members: []domain.TeamMember{
 				{UserID: "u1", Username: "Alice", IsActive: true},
 			},
@@ -135,7 +166,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(nil, errors.New("team not found")).Once()
 
 		mockDB.ExpectBegin()
-		mockDB.ExpectQuery(`INSERT INTO teams`).WithArgs("backend", sqlmock.AnyArg()).
+		mockDB.ExpectQuery(`INSERT INTO teams`).WithArgs("backend", sqlmock.AnyArg(), domain.DefaultMinReviewers, domain.DefaultMaxReviewers).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, time.Now(), updatedTime))
 		mockDB.ExpectQuery(`UPDATE users`).WithArgs(sqlmock.AnyArg(), "Alice", 1, true, sqlmock.AnyArg()).
 			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), nil))
@@ -182,15 +213,21 @@ func TestTeamService_GetTeam(t *testing.T) {
 
 		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
 		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return([]*domain.User{
-			{ID: "u1", Username: "Alice", IsActive: true},
-			{ID: "u2", Username: "Bob", IsActive: true},
+			{ID: "u1", Username: "Alice", IsActive: true, SelectionWeight: 1},
+			{ID: "u2", Username: "Bob", IsActive: true, SelectionWeight: 0.5},
 		}, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{{ID: 2, Name: "platform"}}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{"u7"}, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{}, nil).Once()
 
 		result, err := service.GetTeam(ctx, "backend")
 
 		require.NoError(t, err)
 		assert.Equal(t, team.Name, result.Name)
 		assert.Equal(t, len(team.Members), len(result.Members))
+		assert.Equal(t, 0.5, result.Members[1].SelectionWeight)
+		assert.Equal(t, []string{"platform"}, result.FallbackTeams)
+		assert.Equal(t, []string{"u7"}, result.MandatoryReviewers)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
@@ -212,3 +249,286 @@ func TestTeamService_GetTeam(t *testing.T) {
 		mockTeamRepo.AssertExpectations(t)
 	})
 }
+
+func TestTeamService_UpdateSettings(t *testing.T) {
+	t.Run("успешное обновление настроек", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+		ctx := context.Background()
+
+		team := &domain.Team{ID: 1, Name: "platform", MinReviewers: 1, MaxReviewers: 2}
+		updatedTeam := &domain.Team{ID: 1, Name: "platform", MinReviewers: 2, MaxReviewers: 3}
+
+		mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(team, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{RequiredApprovals: 3}, nil).Once()
+		mockTeamRepo.On("UpdateSettings", mock.Anything, 1, 2, 3).Return(nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(updatedTeam, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return([]*domain.User{}, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{}, nil).Once()
+
+		result, err := service.UpdateSettings(ctx, "platform", 2, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.MinReviewers)
+		assert.Equal(t, 3, result.MaxReviewers)
+		mockTeamRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: min_reviewers больше max_reviewers", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		result, err := service.UpdateSettings(context.Background(), "platform", 3, 2)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "BAD_REQUEST", domainErr.Code)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: max_reviewers меньше required_approvals политики merge", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		team := &domain.Team{ID: 1, Name: "platform", MinReviewers: 1, MaxReviewers: 3}
+		mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(team, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{RequiredApprovals: 3}, nil).Once()
+
+		result, err := service.UpdateSettings(context.Background(), "platform", 1, 2)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockTeamRepo.AssertExpectations(t)
+		mockTeamRepo.AssertNotCalled(t, "UpdateSettings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: команда не найдена", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "nonexistent").Return(nil, errors.New("team not found")).Once()
+
+		result, err := service.UpdateSettings(context.Background(), "nonexistent", 1, 2)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockTeamRepo.AssertExpectations(t)
+	})
+}
+
+func TestTeamService_SetFallbackTeams(t *testing.T) {
+	t.Run("успешная установка резервных команд", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		team := &domain.Team{ID: 1, Name: "backend"}
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(&domain.Team{ID: 2, Name: "platform"}, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "mobile").Return(&domain.Team{ID: 3, Name: "mobile"}, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("DELETE FROM team_fallbacks").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectExec("INSERT INTO team_fallbacks").WithArgs(1, 2, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectExec("INSERT INTO team_fallbacks").WithArgs(1, 3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectCommit()
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return([]*domain.User{}, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{
+			{ID: 2, Name: "platform"},
+			{ID: 3, Name: "mobile"},
+		}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{}, nil).Once()
+
+		result, err := service.SetFallbackTeams(context.Background(), "backend", []string{"platform", "mobile"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"platform", "mobile"}, result.FallbackTeams)
+		mockTeamRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка: команда указана резервной для самой себя", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil).Once()
+
+		result, err := service.SetFallbackTeams(context.Background(), "backend", []string{"backend"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: резервная команда не найдена", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil).Once()
+		mockTeamRepo.On("GetByName", mock.Anything, "nonexistent").Return(nil, errors.New("team not found")).Once()
+
+		result, err := service.SetFallbackTeams(context.Background(), "backend", []string{"nonexistent"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockTeamRepo.AssertExpectations(t)
+	})
+}
+
+func TestTeamService_SetMandatoryReviewers(t *testing.T) {
+	t.Run("успешная установка обязательных ревьюверов", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		team := &domain.Team{ID: 1, Name: "backend"}
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u7").Return(&domain.User{ID: "u7", TeamID: 3, TeamName: "security"}, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("DELETE FROM team_mandatory_reviewers").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockDB.ExpectExec("INSERT INTO team_mandatory_reviewers").WithArgs(1, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectCommit()
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return([]*domain.User{}, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{"u7"}, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(domain.MergePolicy{}, nil).Once()
+
+		result, err := service.SetMandatoryReviewers(context.Background(), "backend", []string{"u7"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u7"}, result.MandatoryReviewers)
+		mockTeamRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка: повтор пользователя", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u7").Return(&domain.User{ID: "u7"}, nil).Once()
+
+		result, err := service.SetMandatoryReviewers(context.Background(), "backend", []string{"u7", "u7"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.SetMandatoryReviewers(context.Background(), "backend", []string{"u999"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestTeamService_SetMergePolicy(t *testing.T) {
+	t.Run("успешная установка политики merge", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		policy := domain.MergePolicy{RequiredApprovals: 2, BlockOnChangesRequested: true}
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend", MaxReviewers: 2}, nil).Twice()
+		mockTeamRepo.On("SetMergePolicy", mock.Anything, 1, policy).Return(nil).Once()
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return([]*domain.User{}, nil).Once()
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil).Once()
+		mockTeamRepo.On("GetMandatoryReviewers", mock.Anything, 1).Return([]string{}, nil).Once()
+		mockTeamRepo.On("GetMergePolicy", mock.Anything, 1).Return(policy, nil).Once()
+
+		result, err := service.SetMergePolicy(context.Background(), "backend", policy)
+
+		require.NoError(t, err)
+		assert.Equal(t, policy, result.MergePolicy)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: отрицательное количество одобрений", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		result, err := service.SetMergePolicy(context.Background(), "backend", domain.MergePolicy{RequiredApprovals: -1})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockTeamRepo.AssertNotCalled(t, "SetMergePolicy", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: одобрений больше max_reviewers команды", func(t *testing.T) {
+		db, _ := setupMockDBForService(t)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewTeamService(db, mockTeamRepo, mockUserRepo)
+
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend", MaxReviewers: 2}, nil).Once()
+
+		result, err := service.SetMergePolicy(context.Background(), "backend", domain.MergePolicy{RequiredApprovals: 3})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockTeamRepo.AssertExpectations(t)
+		mockTeamRepo.AssertNotCalled(t, "SetMergePolicy", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
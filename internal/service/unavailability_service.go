@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+)
+
+// ReassignmentResult - итог одного запуска переназначения ревью отсутствующих пользователей
+type ReassignmentResult struct {
+	// Reassigned - количество переназначенных ревью
+	Reassigned int
+	// Skipped - ID PR, для которых не нашлось замены; отсутствующий ревьювер остается назначенным
+	Skipped []string
+}
+
+type UnavailabilityService interface {
+	Create(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (*domain.Unavailability, error)
+	GetByUserID(ctx context.Context, userID string) ([]*domain.Unavailability, error)
+	Update(ctx context.Context, id int, startsAt, endsAt time.Time, reason string) (*domain.Unavailability, error)
+	Delete(ctx context.Context, id int) error
+	ReassignStartedUnavailability(ctx context.Context) (*ReassignmentResult, error)
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository"
+)
+
+type unavailabilityService struct {
+	unavailabilityRepo repository.UnavailabilityRepository
+	userRepo           repository.UserRepository
+	pullRequestRepo    repository.PullRequestRepository
+	pullRequestService PullRequestService
+}
+
+// NewUnavailabilityService создает сервис периодов отсутствия пользователей.
+// pullRequestService используется для переназначения ревью тех, чье отсутствие началось.
+func NewUnavailabilityService(
+	unavailabilityRepo repository.UnavailabilityRepository,
+	userRepo repository.UserRepository,
+	pullRequestRepo repository.PullRequestRepository,
+	pullRequestService PullRequestService,
+) UnavailabilityService {
+	return &unavailabilityService{
+		unavailabilityRepo: unavailabilityRepo,
+		userRepo:           userRepo,
+		pullRequestRepo:    pullRequestRepo,
+		pullRequestService: pullRequestService,
+	}
+}
+
+func validateUnavailabilityPeriod(startsAt, endsAt time.Time) error {
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return domain.NewBadRequestError("starts_at and ends_at are required")
+	}
+	if !endsAt.After(startsAt) {
+		return domain.NewBadRequestError("ends_at must be after starts_at")
+	}
+	return nil
+}
+
+func (s *unavailabilityService) Create(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (*domain.Unavailability, error) {
+	if err := validateUnavailabilityPeriod(startsAt, endsAt); err != nil {
+		return nil, err
+	}
+
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	unavailability := &domain.Unavailability{
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   reason,
+	}
+
+	err = s.unavailabilityRepo.Create(ctx, unavailability)
+	if err != nil {
+		return nil, err
+	}
+
+	return unavailability, nil
+}
+
+func (s *unavailabilityService) GetByUserID(ctx context.Context, userID string) ([]*domain.Unavailability, error) {
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	return s.unavailabilityRepo.GetByUserID(ctx, userID)
+}
+
+func (s *unavailabilityService) Update(ctx context.Context, id int, startsAt, endsAt time.Time, reason string) (*domain.Unavailability, error) {
+	if err := validateUnavailabilityPeriod(startsAt, endsAt); err != nil {
+		return nil, err
+	}
+
+	unavailability, err := s.unavailabilityRepo.GetByID(ctx, id)
+	if err != nil {
+		if err.Error() == "unavailability not found" {
+			return nil, domain.NewNotFoundError("unavailability with id " + strconv.Itoa(id))
+		}
+		return nil, err
+	}
+
+	unavailability.StartsAt = startsAt
+	unavailability.EndsAt = endsAt
+	unavailability.Reason = reason
+
+	err = s.unavailabilityRepo.Update(ctx, unavailability)
+	if err != nil {
+		if err.Error() == "unavailability not found" {
+			return nil, domain.NewNotFoundError("unavailability with id " + strconv.Itoa(id))
+		}
+		return nil, err
+	}
+
+	updated, err := s.unavailabilityRepo.GetByID(ctx, id)
+	if err != nil {
+		if err.Error() == "unavailability not found" {
+			return nil, domain.NewNotFoundError("unavailability with id " + strconv.Itoa(id))
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *unavailabilityService) Delete(ctx context.Context, id int) error {
+	err := s.unavailabilityRepo.Delete(ctx, id)
+	if err != nil {
+		if err.Error() == "unavailability not found" {
+			return domain.NewNotFoundError("unavailability with id " + strconv.Itoa(id))
+		}
+		return err
+	}
+	return nil
+}
+
+// ReassignStartedUnavailability переназначает OPEN PR пользователей, чье отсутствие уже началось,
+// через PullRequestService.ReassignReviewer, включая PR, где они обязательные ревьюверы.
+// Действующие периоды определяются по часам БД, как и доступность при выборе ревьюверов.
+// PR, для которых замена не нашлась, пропускаются и возвращаются в Skipped; период с такими PR
+// остается необработанным и повторяется при следующем запуске, пока все его PR не будут переназначены.
+func (s *unavailabilityService) ReassignStartedUnavailability(ctx context.Context) (*ReassignmentResult, error) {
+	started, err := s.unavailabilityRepo.GetStartedPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReassignmentResult{Skipped: []string{}}
+	for _, unavailability := range started {
+		skipped := len(result.Skipped)
+		prs, err := s.pullRequestRepo.GetPRsByReviewerID(ctx, unavailability.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			if pr.Status != domain.StatusOpen {
+				continue
+			}
+
+			_, _, err := s.pullRequestService.ReassignReviewer(ctx, pr.ID, unavailability.UserID, true)
+			if err != nil {
+				var domainErr *domain.DomainError
+				if errors.As(err, &domainErr) {
+					result.Skipped = append(result.Skipped, pr.ID)
+					continue
+				}
+				return nil, err
+			}
+			result.Reassigned++
+		}
+
+		if len(result.Skipped) > skipped {
+			continue
+		}
+
+		err = s.unavailabilityRepo.MarkReviewsReassigned(ctx, unavailability.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
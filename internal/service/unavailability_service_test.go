@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnavailabilityService_Create(t *testing.T) {
+	startsAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("успешное создание периода отсутствия", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, mockUserRepo, nil, nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(&domain.User{ID: "u2"}, nil).Once()
+		mockUnavailabilityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Unavailability")).
+			Run(func(args mock.Arguments) { args.Get(1).(*domain.Unavailability).ID = 7 }).
+			Return(nil).Once()
+
+		result, err := service.Create(context.Background(), "u2", startsAt, endsAt, "vacation")
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, result.ID)
+		assert.Equal(t, "u2", result.UserID)
+		assert.Equal(t, "vacation", result.Reason)
+		mockUserRepo.AssertExpectations(t)
+		mockUnavailabilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: конец периода раньше начала", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, mockUserRepo, nil, nil)
+
+		result, err := service.Create(context.Background(), "u2", endsAt, startsAt, "vacation")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockUnavailabilityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, mockUserRepo, nil, nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.Create(context.Background(), "u999", startsAt, endsAt, "vacation")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestUnavailabilityService_Update(t *testing.T) {
+	startsAt := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("успешное обновление периода", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, nil, nil, nil)
+
+		existing := &domain.Unavailability{ID: 7, UserID: "u2", StartsAt: startsAt.Add(-24 * time.Hour), EndsAt: endsAt, Reason: "vacation"}
+		updated := &domain.Unavailability{ID: 7, UserID: "u2", StartsAt: startsAt, EndsAt: endsAt, Reason: "trip"}
+
+		mockUnavailabilityRepo.On("GetByID", mock.Anything, 7).Return(existing, nil).Once()
+		mockUnavailabilityRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.Unavailability) bool {
+			return u.ID == 7 && u.StartsAt.Equal(startsAt) && u.Reason == "trip"
+		})).Return(nil).Once()
+		mockUnavailabilityRepo.On("GetByID", mock.Anything, 7).Return(updated, nil).Once()
+
+		result, err := service.Update(context.Background(), 7, startsAt, endsAt, "trip")
+
+		require.NoError(t, err)
+		assert.Equal(t, "trip", result.Reason)
+		mockUnavailabilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: период не найден", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, nil, nil, nil)
+
+		mockUnavailabilityRepo.On("GetByID", mock.Anything, 999).Return(nil, errors.New("unavailability not found")).Once()
+
+		result, err := service.Update(context.Background(), 999, startsAt, endsAt, "trip")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUnavailabilityRepo.AssertExpectations(t)
+	})
+}
+
+func TestUnavailabilityService_Delete(t *testing.T) {
+	t.Run("ошибка: период не найден", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, nil, nil, nil)
+
+		mockUnavailabilityRepo.On("Delete", mock.Anything, 999).Return(errors.New("unavailability not found")).Once()
+
+		err := service.Delete(context.Background(), 999)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUnavailabilityRepo.AssertExpectations(t)
+	})
+}
+
+func TestUnavailabilityService_ReassignStartedUnavailability(t *testing.T) {
+	absentUser := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true, Unavailable: true}
+	team := &domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 2}
+	teamMembers := []*domain.User{
+		{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true},
+		absentUser,
+		{ID: "u3", Username: "Charlie", TeamID: 1, TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("OPEN PR отсутствующего ревьювера переназначаются, период отмечается", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		prService := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+		service := NewUnavailabilityService(mockUnavailabilityRepo, mockUserRepo, mockPRRepo, prService)
+
+		mockUnavailabilityRepo.On("GetStartedPending", mock.Anything).
+			Return([]*domain.Unavailability{{ID: 7, UserID: "u2"}}, nil).Once()
+		mockPRRepo.On("GetPRsByReviewerID", mock.Anything, "u2").Return([]*domain.PullRequestShort{
+			{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen},
+			{ID: "pr-2", AuthorID: "u1", Status: domain.StatusMerged},
+		}, nil).Once()
+
+		// pr-1: отсутствующий u2 заменяется на u3
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2"}}, nil).Once()
+		mockPRRepo.On("ReplaceReviewer", mock.Anything, "pr-1", "u2", "u3").Return(nil).Once()
+		mockPRRepo.On("CreateAssignment", mock.Anything, mock.AnythingOfType("*domain.Assignment")).Return(nil).Once()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").
+			Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u3"}}, nil).Once()
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(absentUser, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil)
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil)
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil)
+		mockUnavailabilityRepo.On("MarkReviewsReassigned", mock.Anything, 7).Return(nil).Once()
+
+		result, err := service.ReassignStartedUnavailability(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Reassigned)
+		assert.Empty(t, result.Skipped)
+		mockPRRepo.AssertNotCalled(t, "GetByID", mock.Anything, "pr-2")
+		mockPRRepo.AssertExpectations(t)
+		mockUnavailabilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("период с PR без замены остается необработанным", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+
+		prService := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo, nil, NewRandomSelector(), nil)
+		service := NewUnavailabilityService(mockUnavailabilityRepo, mockUserRepo, mockPRRepo, prService)
+
+		mockUnavailabilityRepo.On("GetStartedPending", mock.Anything).
+			Return([]*domain.Unavailability{{ID: 7, UserID: "u2"}}, nil).Once()
+		mockPRRepo.On("GetPRsByReviewerID", mock.Anything, "u2").Return([]*domain.PullRequestShort{
+			{ID: "pr-3", AuthorID: "u1", Status: domain.StatusOpen},
+		}, nil).Once()
+
+		// pr-3: u3 уже назначен, замены нет
+		mockPRRepo.On("GetByID", mock.Anything, "pr-3").
+			Return(&domain.PullRequest{ID: "pr-3", AuthorID: "u1", Status: domain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}, nil).Once()
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(absentUser, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, nil)
+		mockTeamRepo.On("GetFallbackTeams", mock.Anything, 1).Return([]*domain.Team{}, nil)
+		mockUserRepo.On("GetByTeamID", mock.Anything, 1).Return(teamMembers, nil)
+
+		result, err := service.ReassignStartedUnavailability(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Reassigned)
+		assert.Equal(t, []string{"pr-3"}, result.Skipped)
+		mockUnavailabilityRepo.AssertNotCalled(t, "MarkReviewsReassigned", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка БД прерывает обработку без отметки", func(t *testing.T) {
+		mockUnavailabilityRepo := new(mocks.MockUnavailabilityRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUnavailabilityService(mockUnavailabilityRepo, nil, mockPRRepo, nil)
+
+		mockUnavailabilityRepo.On("GetStartedPending", mock.Anything).
+			Return([]*domain.Unavailability{{ID: 7, UserID: "u2"}}, nil).Once()
+		mockPRRepo.On("GetPRsByReviewerID", mock.Anything, "u2").Return(nil, errors.New("connection refused")).Once()
+
+		result, err := service.ReassignStartedUnavailability(context.Background())
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		mockUnavailabilityRepo.AssertNotCalled(t, "MarkReviewsReassigned", mock.Anything, mock.Anything)
+	})
+}
@@ -7,6 +7,12 @@ import (
 )
 
 type UserService interface {
-	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetIsActive(ctx context.Context, userID string, isActive, reassignOpenReviews bool) (*domain.User, []*domain.ReviewerReplacement, error)
 	GetReviewPRs(ctx context.Context, userID string) ([]*domain.PullRequestShort, error)
+	GetAwaitingReviewPRs(ctx context.Context, userID string) ([]*domain.PullRequestShort, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) (*domain.User, error)
+	SetSelectionWeight(ctx context.Context, userID string, weight float64) (*domain.User, error)
+	GetReviewLoad(ctx context.Context, userID string) (*domain.ReviewLoad, error)
+	BulkDeactivate(ctx context.Context, teamName string, userIDs []string) (*domain.BulkDeactivationResult, error)
+	SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error)
 }
@@ -2,24 +2,228 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/repository"
+	"github.com/bagdasarian/avito-pr-reviewer/internal/repository/postgres"
 )
 
 type userService struct {
+	db              *sql.DB
 	userRepo        repository.UserRepository
 	pullRequestRepo repository.PullRequestRepository
+	selector        ReviewerSelector
+	seeder          *AssignmentSeeder
 }
 
-func NewUserService(userRepo repository.UserRepository, pullRequestRepo repository.PullRequestRepository) UserService {
+// NewUserService создает новый экземпляр UserService.
+// db, selector и seeder используются для переназначения ревью при деактивации пользователя.
+func NewUserService(
+	db *sql.DB,
+	userRepo repository.UserRepository,
+	pullRequestRepo repository.PullRequestRepository,
+	selector ReviewerSelector,
+	seeder *AssignmentSeeder,
+) UserService {
 	return &userService{
+		db:              db,
 		userRepo:        userRepo,
 		pullRequestRepo: pullRequestRepo,
+		selector:        selector,
+		seeder:          seeder,
 	}
 }
 
-func (s *userService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+// SetIsActive устанавливает флаг активности пользователя.
+// Если пользователь деактивируется и reassignOpenReviews = true, он заменяется на всех OPEN PR
+// в той же транзакции; при ошибке замены на любом PR изменения откатываются.
+func (s *userService) SetIsActive(ctx context.Context, userID string, isActive, reassignOpenReviews bool) (*domain.User, []*domain.ReviewerReplacement, error) {
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, nil, err
+	}
+
+	replacements := []*domain.ReviewerReplacement{}
+	if !isActive && reassignOpenReviews {
+		replacements, err = s.deactivateAndReassign(ctx, userID)
+	} else {
+		err = s.userRepo.SetIsActive(ctx, userID, isActive)
+	}
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, nil, err
+	}
+
+	updatedUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, nil, err
+	}
+
+	return updatedUser, replacements, nil
+}
+
+// deactivateAndReassign деактивирует пользователя и заменяет его на всех OPEN PR в одной транзакции
+func (s *userService) deactivateAndReassign(ctx context.Context, userID string) ([]*domain.ReviewerReplacement, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = postgres.NewUserRepositoryWithTx(tx).SetIsActive(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	prServiceWithTx := newPullRequestServiceWithTx(tx, s.selector, s.seeder)
+	replacements, _, err := reassignOpenReviews(ctx, prServiceWithTx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return replacements, nil
+}
+
+// BulkDeactivate деактивирует участников команды teamName либо пользователей userIDs (задается ровно одно)
+// и в одной транзакции переназначает их OPEN PR на оставшихся активных участников той же команды.
+// PR, для которых замены не нашлось, сохраняют прежнего ревьювера и попадают в Unreassigned.
+// Стратегия выбора работает в той же транзакции, поэтому нагрузка ревьюверов учитывает замены,
+// уже сделанные для предыдущих пользователей, а курсор ротации откатывается вместе с транзакцией.
+func (s *userService) BulkDeactivate(ctx context.Context, teamName string, userIDs []string) (*domain.BulkDeactivationResult, error) {
+	if (teamName == "") == (len(userIDs) == 0) {
+		return nil, domain.NewBadRequestError("exactly one of team_name or user_ids is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	userRepoWithTx := postgres.NewUserRepositoryWithTx(tx)
+
+	if teamName != "" {
+		team, err := postgres.NewTeamRepositoryWithTx(tx).GetByName(ctx, teamName)
+		if err != nil {
+			if err.Error() == "team not found" {
+				return nil, domain.NewNotFoundError("team with name " + teamName)
+			}
+			return nil, err
+		}
+
+		members, err := userRepoWithTx.GetByTeamID(ctx, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = make([]string, 0, len(members))
+		for _, member := range members {
+			userIDs = append(userIDs, member.ID)
+		}
+	}
+
+	result := &domain.BulkDeactivationResult{
+		DeactivatedUserIDs: make([]string, 0, len(userIDs)),
+		Replacements:       []*domain.ReviewerReplacement{},
+		Unreassigned:       []*domain.UnreassignedReview{},
+	}
+
+	// Сначала деактивируем всех, чтобы никто из них не был выбран заменой для другого
+	deactivated := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if deactivated[userID] {
+			continue
+		}
+		err := userRepoWithTx.SetIsActive(ctx, userID, false)
+		if err != nil {
+			if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+				return nil, domain.NewNotFoundError("user with id " + userID)
+			}
+			return nil, err
+		}
+		deactivated[userID] = true
+		result.DeactivatedUserIDs = append(result.DeactivatedUserIDs, userID)
+	}
+
+	prServiceWithTx := newPullRequestServiceWithTx(tx, s.selector, s.seeder)
+	for _, userID := range result.DeactivatedUserIDs {
+		replacements, unreassigned, err := reassignOpenReviews(ctx, prServiceWithTx, userID, true)
+		if err != nil {
+			return nil, err
+		}
+		result.Replacements = append(result.Replacements, replacements...)
+		result.Unreassigned = append(result.Unreassigned, unreassigned...)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// reassignOpenReviews заменяет userID на всех его OPEN PR через prService.ReassignReviewer,
+// в том числе там, где он обязательный ревьювер.
+// Если keepOnNoCandidate = true, PR без подходящего кандидата пропускаются и возвращаются вторым значением,
+// иначе ErrNoCandidate возвращается как ошибка.
+func reassignOpenReviews(
+	ctx context.Context,
+	prService *pullRequestService,
+	userID string,
+	keepOnNoCandidate bool,
+) ([]*domain.ReviewerReplacement, []*domain.UnreassignedReview, error) {
+	prs, err := prService.pullRequestRepo.GetPRsByReviewerID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replacements := make([]*domain.ReviewerReplacement, 0, len(prs))
+	unreassigned := make([]*domain.UnreassignedReview, 0)
+	for _, pr := range prs {
+		if pr.Status != domain.StatusOpen {
+			continue
+		}
+
+		updatedPR, newReviewerID, err := prService.ReassignReviewer(ctx, pr.ID, userID, true)
+		if err != nil {
+			if keepOnNoCandidate && errors.Is(err, domain.ErrNoCandidate) {
+				unreassigned = append(unreassigned, &domain.UnreassignedReview{
+					PullRequestID: pr.ID,
+					ReviewerID:    userID,
+					Reason:        err.Error(),
+				})
+				continue
+			}
+			return nil, nil, err
+		}
+
+		replacements = append(replacements, &domain.ReviewerReplacement{
+			PullRequestID:     pr.ID,
+			OldReviewerID:     userID,
+			NewReviewerID:     newReviewerID,
+			AssignedReviewers: updatedPR.AssignedReviewers,
+		})
+	}
+
+	return replacements, unreassigned, nil
+}
+
+func (s *userService) GetReviewPRs(ctx context.Context, userID string) ([]*domain.PullRequestShort, error) {
 	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		if err.Error() == "user not found" {
@@ -28,7 +232,44 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 		return nil, err
 	}
 
-	err = s.userRepo.SetIsActive(ctx, userID, isActive)
+	prs, err := s.pullRequestRepo.GetPRsByReviewerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+// GetAwaitingReviewPRs возвращает OPEN PR, где пользователь назначен ревьювером и еще не одобрил их
+// и не запросил изменения
+func (s *userService) GetAwaitingReviewPRs(ctx context.Context, userID string) ([]*domain.PullRequestShort, error) {
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	return s.pullRequestRepo.GetAwaitingReviewPRs(ctx, userID)
+}
+
+// SetMaxOpenReviews устанавливает ограничение на количество OPEN PR на ревью у пользователя.
+// nil снимает ограничение.
+func (s *userService) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) (*domain.User, error) {
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, domain.NewBadRequestError("max_open_reviews must be non-negative")
+	}
+
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	err = s.userRepo.SetMaxOpenReviews(ctx, userID, maxOpenReviews)
 	if err != nil {
 		if err.Error() == "user not found" {
 			return nil, domain.NewNotFoundError("user with id " + userID)
@@ -47,8 +288,43 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 	return updatedUser, nil
 }
 
-func (s *userService) GetReviewPRs(ctx context.Context, userID string) ([]*domain.PullRequestShort, error) {
+// SetSelectionWeight задает вес пользователя при случайном выборе ревьюверов.
+// Вес должен быть в диапазоне (0, 1]: 1 - обычный участник, меньшие значения снижают вероятность выбора.
+func (s *userService) SetSelectionWeight(ctx context.Context, userID string, weight float64) (*domain.User, error) {
+	if !(weight > 0 && weight <= 1) {
+		return nil, domain.NewBadRequestError("selection_weight must be in range (0, 1]")
+	}
+
 	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	err = s.userRepo.SetSelectionWeight(ctx, userID, weight)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	return updatedUser, nil
+}
+
+// GetReviewLoad возвращает количество OPEN PR на ревью у пользователя и его ограничение
+func (s *userService) GetReviewLoad(ctx context.Context, userID string) (*domain.ReviewLoad, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		if err.Error() == "user not found" {
 			return nil, domain.NewNotFoundError("user with id " + userID)
@@ -61,5 +337,50 @@ func (s *userService) GetReviewPRs(ctx context.Context, userID string) ([]*domai
 		return nil, err
 	}
 
-	return prs, nil
+	openReviews := 0
+	for _, pr := range prs {
+		if pr.Status == domain.StatusOpen {
+			openReviews++
+		}
+	}
+
+	return &domain.ReviewLoad{
+		OpenReviews:    openReviews,
+		MaxOpenReviews: user.MaxOpenReviews,
+	}, nil
+}
+
+// SetSkills заменяет навыки пользователя. Теги приводятся к нижнему регистру, повторы убираются.
+func (s *userService) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	normalized, err := normalizeTags(skills)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" || err.Error() == "invalid user ID" {
+			return nil, domain.NewNotFoundError("user with id " + userID)
+		}
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = postgres.NewUserRepositoryWithTx(tx).SetSkills(ctx, userID, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	user.Skills = normalized
+	return user, nil
 }
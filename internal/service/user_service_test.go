@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/domain"
 	"github.com/bagdasarian/avito-pr-reviewer/internal/mocks"
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 
-		service := NewUserService(mockUserRepo, mockPRRepo)
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
 
 		userID := "u1"
 		user := &domain.User{
@@ -44,7 +45,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 		mockUserRepo.On("SetIsActive", mock.Anything, userID, false).Return(nil).Once()
 		mockUserRepo.On("GetByID", mock.Anything, userID).Return(updatedUser, nil).Once()
 
-		result, err := service.SetIsActive(ctx, userID, false)
+		result, _, err := service.SetIsActive(ctx, userID, false, false)
 
 		require.NoError(t, err)
 		assert.Equal(t, false, result.IsActive)
@@ -57,14 +58,14 @@ func TestUserService_SetIsActive(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 
-		service := NewUserService(mockUserRepo, mockPRRepo)
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
 
 		userID := "u999"
 
 		ctx := context.Background()
 		mockUserRepo.On("GetByID", mock.Anything, userID).Return(nil, errors.New("user not found")).Once()
 
-		result, err := service.SetIsActive(ctx, userID, false)
+		result, _, err := service.SetIsActive(ctx, userID, false, false)
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -76,7 +77,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 
-		service := NewUserService(mockUserRepo, mockPRRepo)
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
 
 		userID := "u1"
 		user := &domain.User{
@@ -92,7 +93,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 		mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
 		mockUserRepo.On("SetIsActive", mock.Anything, userID, false).Return(errors.New("database error")).Once()
 
-		result, err := service.SetIsActive(ctx, userID, false)
+		result, _, err := service.SetIsActive(ctx, userID, false, false)
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -100,12 +101,310 @@ func TestUserService_SetIsActive(t *testing.T) {
 	})
 }
 
+func TestUserService_SetIsActive_ReassignOpenReviews(t *testing.T) {
+	userColumns := []string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}
+	prColumns := []string{"id", "title", "author_id", "status", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}
+
+	// expectReassignPR1 ожидает в транзакции замену u2 на u3 на PR pr-1 (автор u1, единственный свободный кандидат u3)
+	// стратегией strategy с входными данными inputs (JSON); expectSelection задает запросы самой стратегии
+	expectReassignPR1 := func(mockDB sqlmock.Sqlmock, createdAt time.Time, strategy, inputs string, expectSelection func()) {
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", createdAt, nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+				AddRow(1, "backend", 1, 2, createdAt, nil))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(1, "Alice", 1, "backend", true, createdAt, nil, nil, 1.0, false).
+				AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false).
+				AddRow(3, "Charlie", 1, "backend", true, createdAt, nil, nil, 1.0, false))
+		if expectSelection != nil {
+			expectSelection()
+		}
+		mockDB.ExpectQuery("SELECT EXISTS").WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mockDB.ExpectExec("UPDATE pull_request_reviewers SET reviewer_id").WithArgs(3, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(1, "Add feature", 1, "OPEN", createdAt, nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(3, "backend"))
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").
+			WithArgs(1, "REASSIGN", sqlmock.AnyArg(), int64(2), []byte(`["u2"]`), "", []byte(`[]`), []byte(`["u3"]`),
+				[]byte(`[{"source":"TEAM","team_name":"backend","strategy":"`+strategy+`","candidates":["u3"],`+
+					`"excluded":[{"user_id":"u1","reason":"AUTHOR"},{"user_id":"u2","reason":"ALREADY_ASSIGNED"}],"selected":["u3"],`+
+					`"inputs":`+inputs+`}]`),
+				sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, createdAt))
+	}
+
+	t.Run("деактивация с заменой на всех OPEN PR в одной транзакции", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(db, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		createdAt := time.Now()
+		user := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true}
+		deactivatedUser := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: false}
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(user, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("UPDATE users").WithArgs(2, false, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name\\s+FROM pull_request_reviewers").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}).
+				AddRow(1, "Add feature", 1, "OPEN").
+				AddRow(2, "Old feature", 1, "MERGED"))
+		expectReassignPR1(mockDB, createdAt, StrategyRandom, `{"pool":["u1","u2","u3"],"max_reviewers":1,"weights":{"u3":1}}`, nil)
+		mockDB.ExpectCommit()
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(deactivatedUser, nil).Once()
+
+		result, replacements, err := service.SetIsActive(context.Background(), "u2", false, true)
+
+		require.NoError(t, err)
+		assert.False(t, result.IsActive)
+		require.Len(t, replacements, 1, "MERGED PR не переназначается")
+		assert.Equal(t, "pr-1", replacements[0].PullRequestID)
+		assert.Equal(t, "u3", replacements[0].NewReviewerID)
+		assert.Equal(t, []string{"u3"}, replacements[0].AssignedReviewers)
+		mockUserRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("round_robin: курсор ротации сдвигается в транзакции деактивации", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		mockRotationRepo := new(mocks.MockRotationRepository)
+
+		service := NewUserService(db, mockUserRepo, mockPRRepo, NewRoundRobinSelector(mockRotationRepo), nil)
+
+		createdAt := time.Now()
+		user := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true}
+		deactivatedUser := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: false}
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(user, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("UPDATE users").WithArgs(2, false, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name\\s+FROM pull_request_reviewers").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}).AddRow(1, "Add feature", 1, "OPEN"))
+		expectReassignPR1(mockDB, createdAt, StrategyRoundRobin, `{"pool":["u1","u2","u3"],"max_reviewers":1,"weights":{"u3":1},"cursor_id":"u1"}`, func() {
+			mockDB.ExpectExec("INSERT INTO team_rotations").WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mockDB.ExpectQuery("SELECT last_reviewer_id FROM team_rotations").WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"last_reviewer_id"}).AddRow(1))
+			mockDB.ExpectExec("UPDATE team_rotations").WithArgs(1, int64(3), sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		})
+		mockDB.ExpectCommit()
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(deactivatedUser, nil).Once()
+
+		_, replacements, err := service.SetIsActive(context.Background(), "u2", false, true)
+
+		require.NoError(t, err)
+		require.Len(t, replacements, 1)
+		assert.Equal(t, "u3", replacements[0].NewReviewerID)
+		mockRotationRepo.AssertNotCalled(t, "AdvanceCursor", mock.Anything, mock.Anything)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка: нет кандидата на одном из PR, транзакция откатывается", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(db, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		createdAt := time.Now()
+		user := &domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true}
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").Return(user, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("UPDATE users").WithArgs(2, false, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name\\s+FROM pull_request_reviewers").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}).
+				AddRow(1, "Add feature", 1, "OPEN").
+				AddRow(3, "Fix bug", 1, "OPEN"))
+		expectReassignPR1(mockDB, createdAt, StrategyRandom, `{"pool":["u1","u2","u3"],"max_reviewers":1,"weights":{"u3":1}}`, nil)
+
+		// pr-3: u3 уже назначен, других кандидатов нет
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(prColumns).AddRow(3, "Fix bug", 1, "OPEN", createdAt, nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend").AddRow(3, "backend"))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}).
+				AddRow(1, "backend", 1, 2, createdAt, nil))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(1, "Alice", 1, "backend", true, createdAt, nil, nil, 1.0, false).
+				AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false).
+				AddRow(3, "Charlie", 1, "backend", true, createdAt, nil, nil, 1.0, false))
+		mockDB.ExpectQuery("FROM team_fallbacks").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}))
+		mockDB.ExpectRollback()
+
+		result, replacements, err := service.SetIsActive(context.Background(), "u2", false, true)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Nil(t, replacements)
+		assert.True(t, errors.Is(err, domain.ErrNoCandidate))
+		mockUserRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
+
+func TestUserService_BulkDeactivate(t *testing.T) {
+	userColumns := []string{"id", "name", "team_id", "name", "is_active", "created_at", "updated_at", "max_open_reviews", "selection_weight", "unavailable"}
+	teamColumns := []string{"id", "name", "min_reviewers", "max_reviewers", "created_at", "updated_at"}
+
+	t.Run("деактивация всей команды: PR без кандидата сохраняет ревьювера", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		service := NewUserService(db, new(mocks.MockUserRepository), new(mocks.MockPullRequestRepository), NewRandomSelector(), nil)
+
+		createdAt := time.Now()
+		teamMembers := sqlmock.NewRows(userColumns).
+			AddRow(1, "Alice", 1, "backend", false, createdAt, nil, nil, 1.0, false).
+			AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(teamColumns).AddRow(1, "backend", 1, 2, createdAt, nil))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(1, "Alice", 1, "backend", true, createdAt, nil, nil, 1.0, false).
+				AddRow(2, "Bob", 1, "backend", true, createdAt, nil, nil, 1.0, false))
+		mockDB.ExpectExec("UPDATE users").WithArgs(1, false, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectExec("UPDATE users").WithArgs(2, false, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name\\s+FROM pull_request_reviewers").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name\\s+FROM pull_request_reviewers").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}).AddRow(1, "Add feature", 1, "OPEN"))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}).
+				AddRow(1, "Add feature", 1, "OPEN", createdAt, nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(teamColumns).AddRow(1, "backend", 1, 2, createdAt, nil))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).WillReturnRows(teamMembers)
+		mockDB.ExpectQuery("FROM team_fallbacks").WithArgs(1).WillReturnRows(sqlmock.NewRows(teamColumns))
+		mockDB.ExpectCommit()
+
+		result, err := service.BulkDeactivate(context.Background(), "backend", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2"}, result.DeactivatedUserIDs)
+		assert.Empty(t, result.Replacements)
+		require.Len(t, result.Unreassigned, 1)
+		assert.Equal(t, "pr-1", result.Unreassigned[0].PullRequestID)
+		assert.Equal(t, "u2", result.Unreassigned[0].ReviewerID)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("least_loaded: нагрузка читается в транзакции деактивации", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+		service := NewUserService(db, new(mocks.MockUserRepository), mockPRRepo, NewLeastLoadedSelector(mockPRRepo), nil)
+
+		createdAt := time.Now()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("UPDATE users").WithArgs(2, false, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name\\s+FROM pull_request_reviewers").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}).AddRow(1, "Add feature", 1, "OPEN"))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}).
+				AddRow(1, "Add feature", 1, "OPEN", createdAt, nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(2, "backend"))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false))
+		mockDB.ExpectQuery("SELECT id, name, min_reviewers, max_reviewers").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(teamColumns).AddRow(1, "backend", 1, 2, createdAt, nil))
+		mockDB.ExpectQuery("SELECT u.id, u.name, u.team_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(1, "Alice", 1, "backend", true, createdAt, nil, nil, 1.0, false).
+				AddRow(2, "Bob", 1, "backend", false, createdAt, nil, nil, 1.0, false).
+				AddRow(3, "Charlie", 1, "backend", true, createdAt, nil, nil, 1.0, false).
+				AddRow(4, "Dave", 1, "backend", true, createdAt, nil, nil, 1.0, false))
+		// нагрузка с учетом переназначений, уже сделанных в транзакции
+		mockDB.ExpectQuery("SELECT u.id, COUNT\\(pr.id\\)").WithArgs(1, "OPEN").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "count"}).AddRow(3, 0).AddRow(4, 5))
+		mockDB.ExpectQuery("SELECT EXISTS").WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mockDB.ExpectExec("UPDATE pull_request_reviewers SET reviewer_id").WithArgs(3, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectQuery("SELECT pr.id, pr.title, u.id, s.name, pr.created_at, pr.merged_at, pr.closed_at").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at", "closed_at", "tags", "co_authors", "review_states"}).
+				AddRow(1, "Add feature", 1, "OPEN", createdAt, nil, nil, "", "", ""))
+		mockDB.ExpectQuery("SELECT prr.reviewer_id").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "name"}).AddRow(3, "backend"))
+		mockDB.ExpectQuery("INSERT INTO pull_request_assignments").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, createdAt))
+		mockDB.ExpectCommit()
+
+		result, err := service.BulkDeactivate(context.Background(), "", []string{"u2"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Replacements, 1)
+		assert.Equal(t, "u3", result.Replacements[0].NewReviewerID)
+		mockPRRepo.AssertNotCalled(t, "GetOpenReviewCountsByTeamID", mock.Anything, mock.Anything)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка: пользователь из списка не найден, транзакция откатывается", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		service := NewUserService(db, new(mocks.MockUserRepository), new(mocks.MockPullRequestRepository), NewRandomSelector(), nil)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("UPDATE users").WithArgs(1, false, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectExec("UPDATE users").WithArgs(999, false, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockDB.ExpectRollback()
+
+		result, err := service.BulkDeactivate(context.Background(), "", []string{"u1", "u999"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка: не задана ни команда, ни список пользователей", func(t *testing.T) {
+		service := NewUserService(nil, new(mocks.MockUserRepository), new(mocks.MockPullRequestRepository), NewRandomSelector(), nil)
+
+		result, err := service.BulkDeactivate(context.Background(), "", nil)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+	})
+}
+
 func TestUserService_GetReviewPRs(t *testing.T) {
 	t.Run("успешное получение PR для ревью", func(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 
-		service := NewUserService(mockUserRepo, mockPRRepo)
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
 
 		userID := "u1"
 		user := &domain.User{
@@ -149,7 +448,7 @@ func TestUserService_GetReviewPRs(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 
-		service := NewUserService(mockUserRepo, mockPRRepo)
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
 
 		userID := "u1"
 		user := &domain.User{
@@ -177,7 +476,7 @@ func TestUserService_GetReviewPRs(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPullRequestRepository)
 
-		service := NewUserService(mockUserRepo, mockPRRepo)
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
 
 		userID := "u999"
 
@@ -192,3 +491,234 @@ func TestUserService_GetReviewPRs(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserService_GetAwaitingReviewPRs(t *testing.T) {
+	t.Run("успешное получение PR, ожидающих решения", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		prs := []*domain.PullRequestShort{
+			{ID: "pr-2", Title: "Fix bug", AuthorID: "u3", Status: domain.StatusOpen},
+		}
+
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", TeamID: 1, IsActive: true}, nil).Once()
+		mockPRRepo.On("GetAwaitingReviewPRs", mock.Anything, "u1").Return(prs, nil).Once()
+
+		result, err := service.GetAwaitingReviewPRs(context.Background(), "u1")
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "pr-2", result[0].ID)
+		mockUserRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.GetAwaitingReviewPRs(context.Background(), "u999")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockPRRepo.AssertNotCalled(t, "GetAwaitingReviewPRs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_SetMaxOpenReviews(t *testing.T) {
+	t.Run("успешная установка ограничения", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		userID := "u1"
+		limit := 3
+		user := &domain.User{ID: userID, Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true}
+		updatedUser := &domain.User{ID: userID, Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true, MaxOpenReviews: &limit}
+
+		ctx := context.Background()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockUserRepo.On("SetMaxOpenReviews", mock.Anything, userID, &limit).Return(nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(updatedUser, nil).Once()
+
+		result, err := service.SetMaxOpenReviews(ctx, userID, &limit)
+
+		require.NoError(t, err)
+		require.NotNil(t, result.MaxOpenReviews)
+		assert.Equal(t, 3, *result.MaxOpenReviews)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: отрицательное ограничение", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		limit := -1
+		result, err := service.SetMaxOpenReviews(context.Background(), "u1", &limit)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+		mockUserRepo.AssertNotCalled(t, "SetMaxOpenReviews", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		userID := "u999"
+
+		ctx := context.Background()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.SetMaxOpenReviews(ctx, userID, nil)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_SetSelectionWeight(t *testing.T) {
+	t.Run("успешная установка веса", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUserService(nil, mockUserRepo, nil, NewRandomSelector(), nil)
+
+		user := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true, SelectionWeight: 1}
+		updatedUser := &domain.User{ID: "u1", Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true, SelectionWeight: 0.3}
+
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil).Once()
+		mockUserRepo.On("SetSelectionWeight", mock.Anything, "u1", 0.3).Return(nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "u1").Return(updatedUser, nil).Once()
+
+		result, err := service.SetSelectionWeight(context.Background(), "u1", 0.3)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.3, result.SelectionWeight)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("ошибка: вес вне диапазона (0, 1]", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUserService(nil, mockUserRepo, nil, NewRandomSelector(), nil)
+
+		for _, weight := range []float64{0, -0.5, 1.5} {
+			result, err := service.SetSelectionWeight(context.Background(), "u1", weight)
+
+			require.Error(t, err)
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, domain.NewBadRequestError("")), "weight %v", weight)
+		}
+		mockUserRepo.AssertNotCalled(t, "SetSelectionWeight", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUserService(nil, mockUserRepo, nil, NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.SetSelectionWeight(context.Background(), "u999", 0.5)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_GetReviewLoad(t *testing.T) {
+	t.Run("учитываются только OPEN PR", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPullRequestRepository)
+
+		service := NewUserService(nil, mockUserRepo, mockPRRepo, NewRandomSelector(), nil)
+
+		userID := "u1"
+		limit := 2
+		user := &domain.User{ID: userID, Username: "Alice", TeamID: 1, TeamName: "backend", IsActive: true, MaxOpenReviews: &limit}
+		prs := []*domain.PullRequestShort{
+			{ID: "pr-1", Title: "Add feature", AuthorID: "u2", Status: domain.StatusOpen},
+			{ID: "pr-2", Title: "Fix bug", AuthorID: "u3", Status: domain.StatusMerged},
+		}
+
+		ctx := context.Background()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockPRRepo.On("GetPRsByReviewerID", mock.Anything, userID).Return(prs, nil).Once()
+
+		load, err := service.GetReviewLoad(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, load.OpenReviews)
+		require.NotNil(t, load.MaxOpenReviews)
+		assert.Equal(t, 2, *load.MaxOpenReviews)
+		mockUserRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_SetSkills(t *testing.T) {
+	t.Run("успешная установка навыков", func(t *testing.T) {
+		db, mockDB := setupMockDBForService(t)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUserService(db, mockUserRepo, new(mocks.MockPullRequestRepository), NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u2").
+			Return(&domain.User{ID: "u2", Username: "Bob", TeamID: 1, TeamName: "backend", IsActive: true}, nil).Once()
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec("DELETE FROM user_skills").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockDB.ExpectExec("INSERT INTO user_skills").WithArgs(2, "frontend").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectExec("INSERT INTO user_skills").WithArgs(2, "go").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockDB.ExpectCommit()
+
+		result, err := service.SetSkills(context.Background(), "u2", []string{" Go ", "frontend", "go"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frontend", "go"}, result.Skills)
+		mockUserRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("ошибка: пустой тег", func(t *testing.T) {
+		service := NewUserService(nil, new(mocks.MockUserRepository), new(mocks.MockPullRequestRepository), NewRandomSelector(), nil)
+
+		result, err := service.SetSkills(context.Background(), "u2", []string{"go", " "})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.NewBadRequestError("")))
+	})
+
+	t.Run("ошибка: пользователь не найден", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		service := NewUserService(nil, mockUserRepo, new(mocks.MockPullRequestRepository), NewRandomSelector(), nil)
+
+		mockUserRepo.On("GetByID", mock.Anything, "u999").Return(nil, errors.New("user not found")).Once()
+
+		result, err := service.SetSkills(context.Background(), "u999", []string{"go"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		mockUserRepo.AssertExpectations(t)
+	})
+}
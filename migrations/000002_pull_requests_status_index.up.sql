@@ -0,0 +1,2 @@
+-- Индекс для подсчёта OPEN PR на ревью (стратегия least_loaded)
+CREATE INDEX idx_pull_requests_status_id ON pull_requests(status_id);
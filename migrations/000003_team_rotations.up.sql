@@ -0,0 +1,6 @@
+-- Курсор ротации ревьюверов для стратегии round_robin
+CREATE TABLE team_rotations (
+    team_id INTEGER PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    last_reviewer_id INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NULL
+);
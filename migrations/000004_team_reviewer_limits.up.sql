@@ -0,0 +1,5 @@
+-- Настройки количества ревьюверов для команды
+ALTER TABLE teams
+    ADD COLUMN min_reviewers INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN max_reviewers INTEGER NOT NULL DEFAULT 2,
+    ADD CONSTRAINT teams_reviewer_limits_check CHECK (min_reviewers >= 0 AND min_reviewers <= max_reviewers);
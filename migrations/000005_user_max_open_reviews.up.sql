@@ -0,0 +1,3 @@
+-- Ограничение количества OPEN PR на ревью у пользователя (NULL - без ограничения)
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
@@ -0,0 +1,16 @@
+-- Периоды отсутствия пользователей (отпуска, больничные).
+-- reviews_reassigned_at заполняется фоновой задачей после переназначения OPEN PR отсутствующего ревьювера.
+CREATE TABLE user_unavailability (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    reviews_reassigned_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL,
+    CONSTRAINT user_unavailability_period_check CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user_period ON user_unavailability(user_id, starts_at, ends_at);
+CREATE INDEX idx_user_unavailability_pending ON user_unavailability(starts_at) WHERE reviews_reassigned_at IS NULL;
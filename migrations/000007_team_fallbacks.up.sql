@@ -0,0 +1,17 @@
+-- Резервные команды, из которых добираются ревьюверы, если в своей команде кандидатов не хватает
+CREATE TABLE team_fallbacks (
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    fallback_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    priority INTEGER NOT NULL,
+    PRIMARY KEY (team_id, fallback_team_id),
+    CONSTRAINT team_fallbacks_not_self_check CHECK (team_id <> fallback_team_id)
+);
+
+-- Команда, из которой был выбран ревьювер
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN source_team_id INTEGER NULL REFERENCES teams(id) ON DELETE SET NULL;
+
+UPDATE pull_request_reviewers prr
+SET source_team_id = u.team_id
+FROM users u
+WHERE prr.reviewer_id = u.id;
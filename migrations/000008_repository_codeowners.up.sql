@@ -0,0 +1,7 @@
+-- Файлы CODEOWNERS репозиториев, по которым ревьюверы выбираются из владельцев измененных путей
+CREATE TABLE repository_codeowners (
+    repository VARCHAR(255) PRIMARY KEY,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL
+);
@@ -0,0 +1,13 @@
+-- Навыки пользователей (go, sql, frontend, ...)
+CREATE TABLE user_skills (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (user_id, tag)
+);
+
+-- Навыки, требуемые для ревью PR
+CREATE TABLE pull_request_tags (
+    pull_request_id INTEGER NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (pull_request_id, tag)
+);
@@ -0,0 +1,15 @@
+-- История выбора ревьюверов: seed источника случайности и входные данные, позволяющие воспроизвести выбор
+CREATE TABLE pull_request_assignments (
+    id SERIAL PRIMARY KEY,
+    pull_request_id INTEGER NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    event VARCHAR(20) NOT NULL,
+    seed BIGINT NOT NULL,
+    replaced_reviewer_id INTEGER NULL REFERENCES users(id) ON DELETE RESTRICT,
+    previous_reviewers JSONB NOT NULL DEFAULT '[]',
+    repository VARCHAR(255) NULL,
+    changed_files JSONB NOT NULL DEFAULT '[]',
+    selected_reviewers JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pr_assignments_pr_id ON pull_request_assignments(pull_request_id, id);
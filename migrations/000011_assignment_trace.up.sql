@@ -0,0 +1,2 @@
+-- Трассировка выбора ревьюверов: пул кандидатов, исключенные с причинами, стратегия и выбранные на каждом шаге
+ALTER TABLE pull_request_assignments ADD COLUMN steps JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+-- Вес пользователя при случайном выборе ревьюверов (например, 0.3 для новичка, 0.5 для лида)
+ALTER TABLE users
+    ADD COLUMN selection_weight DOUBLE PRECISION NOT NULL DEFAULT 1.0
+        CHECK (selection_weight > 0 AND selection_weight <= 1);
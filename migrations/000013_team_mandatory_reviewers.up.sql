@@ -0,0 +1,6 @@
+-- Обязательные ревьюверы команды (например, security champion), назначаемые на каждый PR ее участников
+CREATE TABLE team_mandatory_reviewers (
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, user_id)
+);
@@ -0,0 +1,8 @@
+-- Соавторы PR (например, при парном программировании): не назначаются ревьюверами и учитываются в статистике авторства
+CREATE TABLE pull_request_co_authors (
+    pull_request_id INTEGER NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    PRIMARY KEY (pull_request_id, user_id)
+);
+
+CREATE INDEX idx_pr_co_authors_user_id ON pull_request_co_authors(user_id);
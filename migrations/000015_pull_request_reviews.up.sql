@@ -0,0 +1,10 @@
+-- Решения ревьюверов по PR; актуальным считается последнее решение каждого ревьювера
+CREATE TABLE pull_request_reviews (
+    id SERIAL PRIMARY KEY,
+    pull_request_id INTEGER NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    state VARCHAR(20) NOT NULL CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pr_reviews_pr_reviewer ON pull_request_reviews(pull_request_id, reviewer_id, id);
@@ -0,0 +1,19 @@
+-- Политика merge для PR участников команды; команда без записи merge не ограничивает
+CREATE TABLE team_merge_policies (
+    team_id INTEGER PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    required_approvals INTEGER NOT NULL DEFAULT 0 CHECK (required_approvals >= 0),
+    block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Журнал merge в обход политики (admin override)
+CREATE TABLE pull_request_merge_overrides (
+    id SERIAL PRIMARY KEY,
+    pull_request_id INTEGER NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    violation TEXT NOT NULL,
+    approvals INTEGER NOT NULL,
+    required_approvals INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pr_merge_overrides_pr_id ON pull_request_merge_overrides(pull_request_id);
@@ -0,0 +1,2 @@
+-- Черновики (ревьюверы назначаются после перевода в OPEN) и PR, закрытые без merge
+INSERT INTO statuses (name) VALUES ('DRAFT'), ('CLOSED');
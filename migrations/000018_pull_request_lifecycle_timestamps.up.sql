@@ -0,0 +1,15 @@
+-- Время merge и закрытия PR хранится отдельно от updated_at, которое меняется при любом изменении PR
+ALTER TABLE pull_requests
+    ADD COLUMN merged_at TIMESTAMP NULL,
+    ADD COLUMN closed_at TIMESTAMP NULL;
+
+-- Для уже смерженных и закрытых PR время перехода известно только из updated_at
+UPDATE pull_requests pr
+SET merged_at = COALESCE(pr.updated_at, pr.created_at)
+FROM statuses s
+WHERE pr.status_id = s.id AND s.name = 'MERGED';
+
+UPDATE pull_requests pr
+SET closed_at = COALESCE(pr.updated_at, pr.created_at)
+FROM statuses s
+WHERE pr.status_id = s.id AND s.name = 'CLOSED';
@@ -0,0 +1,5 @@
+-- Индексы для списка PR: сортировка и курсор по (created_at, id), фильтры по автору и статусу.
+-- Фильтр по ревьюверу использует idx_pr_reviewers_reviewer_id.
+CREATE INDEX idx_pull_requests_created_at_id ON pull_requests(created_at, id);
+CREATE INDEX idx_pull_requests_author_created_at ON pull_requests(author_id, created_at, id);
+CREATE INDEX idx_pull_requests_status_created_at ON pull_requests(status_id, created_at, id);
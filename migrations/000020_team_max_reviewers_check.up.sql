@@ -0,0 +1,7 @@
+-- Команда без ревьюверов не допускается: max_reviewers должен быть не меньше 1.
+-- Строки с max_reviewers = 0 (min_reviewers тогда тоже 0) получают значение по умолчанию.
+UPDATE teams SET max_reviewers = 2 WHERE max_reviewers = 0;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_reviewer_limits_check,
+    ADD CONSTRAINT teams_reviewer_limits_check CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
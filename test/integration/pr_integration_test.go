@@ -24,11 +24,13 @@ func TestCreatePRWithAutoReviewers(t *testing.T) {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 
 	// 1. Создаём команду с несколькими пользователями
 	team := &domain.Team{
-		Name: "backend",
+		Name:         "backend",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Alice", IsActive: true},
 			{UserID: "u2", Username: "Bob", IsActive: true},
@@ -40,7 +42,7 @@ func TestCreatePRWithAutoReviewers(t *testing.T) {
 	require.NotNil(t, createdTeam)
 
 	// 2. Создаём PR от пользователя u1
-	pr, err := prService.CreatePR(ctx, "pr-1", "Test PR", "u1")
+	pr, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-1", Title: "Test PR", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotNil(t, pr)
 
@@ -71,11 +73,13 @@ func TestCreatePRWithoutAvailableReviewers(t *testing.T) {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 
 	// Создаём команду только с автором (нет других активных пользователей)
 	team := &domain.Team{
-		Name: "solo",
+		Name:         "solo",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Solo", IsActive: true},
 		},
@@ -84,7 +88,7 @@ func TestCreatePRWithoutAvailableReviewers(t *testing.T) {
 	require.NoError(t, err)
 
 	// Создаём PR
-	pr, err := prService.CreatePR(ctx, "pr-2", "Solo PR", "u1")
+	pr, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-2", Title: "Solo PR", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotNil(t, pr)
 
@@ -101,11 +105,13 @@ func TestCreatePRWithInactiveUsers(t *testing.T) {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 
 	// Создаём команду с активным автором и неактивными пользователями
 	team := &domain.Team{
-		Name: "mixed",
+		Name:         "mixed",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Active", IsActive: true},
 			{UserID: "u2", Username: "Inactive1", IsActive: false},
@@ -116,7 +122,7 @@ func TestCreatePRWithInactiveUsers(t *testing.T) {
 	require.NoError(t, err)
 
 	// Создаём PR
-	pr, err := prService.CreatePR(ctx, "pr-3", "Mixed PR", "u1")
+	pr, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-3", Title: "Mixed PR", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotNil(t, pr)
 
@@ -133,11 +139,13 @@ func TestReassignReviewer(t *testing.T) {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 
 	// Создаём команду с несколькими пользователями
 	team := &domain.Team{
-		Name: "backend",
+		Name:         "backend",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Alice", IsActive: true},
 			{UserID: "u2", Username: "Bob", IsActive: true},
@@ -149,7 +157,7 @@ func TestReassignReviewer(t *testing.T) {
 	require.NoError(t, err)
 
 	// Создаём PR
-	pr, err := prService.CreatePR(ctx, "pr-4", "Test PR", "u1")
+	pr, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-4", Title: "Test PR", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotNil(t, pr)
 	require.NotEmpty(t, pr.AssignedReviewers, "должен быть назначен хотя бы один ревьювер")
@@ -157,7 +165,7 @@ func TestReassignReviewer(t *testing.T) {
 	oldReviewerID := pr.AssignedReviewers[0]
 
 	// Переназначаем ревьювера
-	updatedPR, newReviewerID, err := prService.ReassignReviewer(ctx, "pr-4", oldReviewerID)
+	updatedPR, newReviewerID, err := prService.ReassignReviewer(ctx, "pr-4", oldReviewerID, false)
 	require.NoError(t, err)
 	require.NotNil(t, updatedPR)
 	require.NotEmpty(t, newReviewerID)
@@ -185,11 +193,13 @@ func TestMergePRAndBlockChanges(t *testing.T) {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 
 	// Создаём команду и PR
 	team := &domain.Team{
-		Name: "backend",
+		Name:         "backend",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Alice", IsActive: true},
 			{UserID: "u2", Username: "Bob", IsActive: true},
@@ -199,20 +209,20 @@ func TestMergePRAndBlockChanges(t *testing.T) {
 	_, err := teamService.CreateTeam(ctx, team)
 	require.NoError(t, err)
 
-	pr, err := prService.CreatePR(ctx, "pr-5", "Test PR", "u1")
+	pr, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-5", Title: "Test PR", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotEmpty(t, pr.AssignedReviewers)
 
 	oldReviewerID := pr.AssignedReviewers[0]
 
 	// Merge PR
-	mergedPR, err := prService.MergePR(ctx, "pr-5")
+	mergedPR, err := prService.MergePR(ctx, "pr-5", false, "")
 	require.NoError(t, err)
 	require.NotNil(t, mergedPR)
 	assert.Equal(t, domain.StatusMerged, mergedPR.Status)
 
 	// Пытаемся переназначить ревьювера после merge
-	_, _, err = prService.ReassignReviewer(ctx, "pr-5", oldReviewerID)
+	_, _, err = prService.ReassignReviewer(ctx, "pr-5", oldReviewerID, false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "merged", "должна быть ошибка при попытке изменить ревьюверов после merge")
 }
@@ -226,11 +236,13 @@ func TestMergePRIdempotency(t *testing.T) {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 
 	// Создаём команду и PR
 	team := &domain.Team{
-		Name: "backend",
+		Name:         "backend",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Alice", IsActive: true},
 			{UserID: "u2", Username: "Bob", IsActive: true},
@@ -239,22 +251,21 @@ func TestMergePRIdempotency(t *testing.T) {
 	_, err := teamService.CreateTeam(ctx, team)
 	require.NoError(t, err)
 
-	_, err = prService.CreatePR(ctx, "pr-6", "Test PR", "u1")
+	_, err = prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-6", Title: "Test PR", AuthorID: "u1"})
 	require.NoError(t, err)
 
 	// Первый merge
-	mergedPR1, err := prService.MergePR(ctx, "pr-6")
+	mergedPR1, err := prService.MergePR(ctx, "pr-6", false, "")
 	require.NoError(t, err)
 	assert.Equal(t, domain.StatusMerged, mergedPR1.Status)
 
 	// Второй merge (идемпотентность)
-	mergedPR2, err := prService.MergePR(ctx, "pr-6")
+	mergedPR2, err := prService.MergePR(ctx, "pr-6", false, "")
 	require.NoError(t, err, "повторный merge не должен вызывать ошибку")
 	assert.Equal(t, domain.StatusMerged, mergedPR2.Status)
 	assert.NotNil(t, mergedPR2.MergedAt)
 
 	// Проверяем, что PR действительно в статусе MERGED
-	_, err = prService.MergePR(ctx, "pr-6")
+	_, err = prService.MergePR(ctx, "pr-6", false, "")
 	require.NoError(t, err, "третий merge также должен быть успешным (идемпотентность)")
 }
-
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
 	"time"
 
@@ -58,26 +59,28 @@ func setupTestDB(t *testing.T) *sql.DB {
 }
 
 func applyMigrations(t *testing.T, db *sql.DB) {
-	// Пробуем разные пути к миграции
-	var migrationSQL []byte
-	var err error
-	
-	paths := []string{
-		filepath.Join("..", "..", "migrations", "000001_init.up.sql"),
-		filepath.Join("migrations", "000001_init.up.sql"),
-		filepath.Join("..", "migrations", "000001_init.up.sql"),
-	}
-	
-	for _, path := range paths {
-		migrationSQL, err = os.ReadFile(path)
-		if err == nil {
+	// Пробуем разные пути к каталогу миграций
+	var migrationFiles []string
+	for _, dir := range []string{
+		filepath.Join("..", "..", "migrations"),
+		"migrations",
+		filepath.Join("..", "migrations"),
+	} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+		if err == nil && len(files) > 0 {
+			migrationFiles = files
 			break
 		}
 	}
-	require.NoError(t, err, "не удалось прочитать файл миграции. Проверьте, что файл migrations/000001_init.up.sql существует")
+	require.NotEmpty(t, migrationFiles, "не удалось найти файлы миграций. Проверьте, что каталог migrations существует")
 
-	// Выполняем миграцию
-	_, err = db.Exec(string(migrationSQL))
-	require.NoError(t, err, "не удалось применить миграцию")
-}
+	// Накатываем миграции по порядку номеров
+	sort.Strings(migrationFiles)
+	for _, path := range migrationFiles {
+		migrationSQL, err := os.ReadFile(path)
+		require.NoError(t, err, "не удалось прочитать файл миграции %s", path)
 
+		_, err = db.Exec(string(migrationSQL))
+		require.NoError(t, err, "не удалось применить миграцию %s", path)
+	}
+}
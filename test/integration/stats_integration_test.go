@@ -24,12 +24,14 @@ func TestStatsIntegration(t *testing.T) {
 	statsRepo := postgres.NewStatsRepository(db)
 
 	teamService := service.NewTeamService(db, teamRepo, userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
+	prService := service.NewPullRequestService(db, prRepo, userRepo, teamRepo, postgres.NewCodeOwnersRepository(db), service.NewRandomSelector(), nil)
 	statsService := service.NewStatsService(statsRepo)
 
 	// Создаём команду с несколькими пользователями
 	team := &domain.Team{
-		Name: "backend",
+		Name:         "backend",
+		MinReviewers: domain.DefaultMinReviewers,
+		MaxReviewers: domain.DefaultMaxReviewers,
 		Members: []domain.TeamMember{
 			{UserID: "u1", Username: "Alice", IsActive: true},
 			{UserID: "u2", Username: "Bob", IsActive: true},
@@ -40,11 +42,11 @@ func TestStatsIntegration(t *testing.T) {
 	require.NoError(t, err)
 
 	// Создаём несколько PR
-	pr1, err := prService.CreatePR(ctx, "pr-1", "PR 1", "u1")
+	pr1, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-1", Title: "PR 1", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotEmpty(t, pr1.AssignedReviewers)
 
-	pr2, err := prService.CreatePR(ctx, "pr-2", "PR 2", "u1")
+	pr2, err := prService.CreatePR(ctx, service.CreatePRInput{PullRequestID: "pr-2", Title: "PR 2", AuthorID: "u1"})
 	require.NoError(t, err)
 	require.NotEmpty(t, pr2.AssignedReviewers)
 
@@ -84,7 +86,7 @@ func TestStatsIntegration(t *testing.T) {
 
 	// Проверяем статистику по статусам
 	assert.Greater(t, len(prStatusStats), 0, "должна быть статистика по статусам PR")
-	
+
 	// Проверяем, что есть PR со статусом OPEN
 	openCount := 0
 	for _, stat := range prStatusStats {
@@ -95,4 +97,3 @@ func TestStatsIntegration(t *testing.T) {
 	}
 	assert.Greater(t, openCount, 0, "должен быть хотя бы один PR со статусом OPEN")
 }
-